@@ -2,114 +2,349 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/subtle"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
+	goruntime "runtime"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/shipwright-io/build/server/pkg/audit"
+	"github.com/shipwright-io/build/server/pkg/config"
+	"github.com/shipwright-io/build/server/pkg/metrics"
 	"github.com/shipwright-io/build/server/pkg/tools"
+	"github.com/shipwright-io/build/server/pkg/tracing"
 )
 
-var k8sClient client.Client
+const readOnlyInstructions = "This server runs in read-only mode. Only tools that list, get, diff, export or diagnose Shipwright resources are available; creating, restarting and deleting Builds and BuildRuns is disabled."
+
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// version is set at build time with -ldflags "-X main.version=<version>".
+var version = "dev"
+
+const readinessTimeout = 5 * time.Second
+
+var configFile = flag.String("config", os.Getenv("SHIPWRIGHT_MCP_CONFIG"), "Path to a YAML configuration file")
+
+var readOnly = flag.Bool("read-only", false, "Register only tools that do not modify the cluster")
+
+var enableTools = flag.String("enable-tools", "", "Comma-separated tool names or globs to register, all tools when empty")
+
+var disableTools = flag.String("disable-tools", "", "Comma-separated tool names or globs to leave out")
 
 func main() {
-	log.SetOutput(os.Stderr)
-	log.Printf("Starting Shipwright Build MCP Server v1.2.0")
+	flag.Parse()
 
-	config, err := rest.InClusterConfig()
+	cfg, err := config.Load(*configFile)
 	if err != nil {
-		log.Printf("Not running in cluster, trying kubeconfig...")
-		config, err = clientcmd.BuildConfigFromFlags("", clientcmd.RecommendedHomeFile)
-		if err != nil {
-			log.Fatalf("Failed to create Kubernetes config: %v", err)
+		fatal("Failed to load configuration", err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "read-only":
+			cfg.ReadOnly = *readOnly
+		case "enable-tools":
+			cfg.Tools.Enable = config.SplitList(*enableTools)
+		case "disable-tools":
+			cfg.Tools.Disable = config.SplitList(*disableTools)
 		}
+	})
+	if err := cfg.Validate(); err != nil {
+		fatal("Failed to validate configuration", err)
+	}
+
+	level, _ := cfg.Level()
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+	slog.Info("Starting Shipwright Build MCP Server", "version", version)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, version)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
+	disable := cfg.Tools.Disable
+	if cfg.Transport == config.TransportHTTP && !cfg.LocalSource.EnableOverHTTP {
+		disable = append(slices.Clone(disable), tools.LocalSourceTool)
+	}
+	definitions, err := tools.SelectTools(cfg.ReadOnly, cfg.Tools.Enable, disable)
+	if err != nil {
+		fatal("Invalid tool selection", err)
 	}
 
 	scheme := runtime.NewScheme()
 	if err := buildv1beta1.AddToScheme(scheme); err != nil {
-		log.Fatalf("Failed to add build scheme: %v", err)
+		fatal("Failed to add build scheme", err)
 	}
 
-	k8sClient, err = client.New(config, client.Options{Scheme: scheme})
+	clusters, err := loadClusters(cfg, scheme)
 	if err != nil {
-		log.Fatalf("Failed to create Kubernetes client: %v", err)
+		fatal("Failed to create Kubernetes clients", err)
+	}
+	for i, cluster := range clusters {
+		tools.AddCluster(cluster, i == 0)
+		slog.Info("Kubernetes client initialized", "context", cluster.Name, "host", cluster.Host, "namespace", cluster.Namespace, "default", i == 0)
 	}
 
-	tools.SetClient(k8sClient)
+	tools.SetBundleRegistry(cfg.BundleRegistry, cfg.BundleInsecure)
+	localSourceRoots := cfg.LocalSource.Roots
+	if len(localSourceRoots) == 0 {
+		workingDirectory, err := os.Getwd()
+		if err != nil {
+			fatal("Failed to get working directory", err)
+		}
+		localSourceRoots = []string{workingDirectory}
+	}
+	tools.SetLocalSourceRoots(localSourceRoots)
+	tools.SetDefaults(cfg.DefaultNamespace, cfg.DefaultStrategy, cfg.DefaultStrategyKind)
+	tools.SetAllowedNamespaces(cfg.AllowedNamespaces)
+	tools.SetLogToClient(cfg.LogToClient)
 
-	log.Printf("Kubernetes client initialized")
+	switch cfg.Audit.Sink {
+	case config.AuditSinkStderr:
+		tools.SetAuditSink(audit.NewJSONLinesSink(os.Stderr))
+	case config.AuditSinkFile:
+		sink, err := audit.NewFileSink(cfg.Audit.File)
+		if err != nil {
+			fatal("Failed to open audit file", err)
+		}
+		tools.SetAuditSink(sink)
+	case config.AuditSinkEvent:
+		tools.SetAuditSink(audit.NewEventSink(tools.ClusterClientset))
+	}
 
-	server := mcp.NewServer(&mcp.Implementation{
+	implementation := &mcp.Implementation{
 		Name:    "shipwright-build-mcp-server",
-		Version: "v1.2.0",
-	}, nil)
-
-	log.Printf("Registering MCP tools...")
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "list_builds",
-		Description: "List Builds in a namespace with filtering options",
-	}, tools.ListBuilds)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get_build",
-		Description: "Get a specific Build by name",
-	}, tools.GetBuild)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "create_build",
-		Description: "Create a new Build resource",
-	}, tools.CreateBuild)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "delete_build",
-		Description: "Delete a Build resource",
-	}, tools.DeleteBuild)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "list_buildruns",
-		Description: "List BuildRuns in a namespace with filtering options",
-	}, tools.ListBuildRuns)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get_buildrun",
-		Description: "Get a specific BuildRun by name",
-	}, tools.GetBuildRun)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "create_buildrun",
-		Description: "Create a new BuildRun resource (either from existing Build or inline)",
-	}, tools.CreateBuildRun)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "restart_buildrun",
-		Description: "Restart a BuildRun by creating a new one",
-	}, tools.RestartBuildRun)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "delete_buildrun",
-		Description: "Delete a BuildRun resource",
-	}, tools.DeleteBuildRun)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "list_buildstrategies",
-		Description: "List BuildStrategies in a namespace with filtering options",
-	}, tools.ListBuildStrategies)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "list_clusterbuildstrategies",
-		Description: "List ClusterBuildStrategies with filtering options",
-	}, tools.ListClusterBuildStrategies)
-
-	log.Printf("MCP Server listening on stdin/stdout")
-	log.Printf("Available tools: list_builds, get_build, create_build, delete_build, list_buildruns, get_buildrun, create_buildrun, restart_buildrun, delete_buildrun, list_buildstrategies, list_clusterbuildstrategies")
-
-	if err := server.Run(context.Background(), mcp.NewStdioTransport()); err != nil {
-		log.Fatal(err)
+		Version: version,
+	}
+	serverOptions := &mcp.ServerOptions{
+		InitializedHandler: func(ctx context.Context, req *mcp.InitializedRequest) {
+			metrics.ActiveSessions.Inc()
+			go func() {
+				_ = req.Session.Wait()
+				metrics.ActiveSessions.Dec()
+			}()
+		},
+	}
+	if cfg.ReadOnly {
+		implementation.Title = "Shipwright Build MCP Server (read-only)"
+		serverOptions.Instructions = readOnlyInstructions
+	}
+
+	server := mcp.NewServer(implementation, serverOptions)
+
+	slog.Debug("Registering MCP tools")
+
+	names := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		definition.Register(server)
+		names = append(names, definition.Name)
+	}
+	go tools.Preflight(context.Background())
+	tools.StartCaches(context.Background())
+
+	if cfg.ReadOnly {
+		slog.Info("Read-only mode, mutating tools are disabled")
+	}
+	slog.Info("Available tools", "tools", strings.Join(names, ", "))
+
+	switch cfg.Transport {
+	case config.TransportHTTP:
+		requireToken := func(handler http.Handler) http.Handler { return handler }
+		if cfg.HTTPToken != "" {
+			requireToken = auth.RequireBearerToken(staticTokenVerifier(cfg.HTTPToken, cfg.TokenUser()), nil)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/mcp", requireToken(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+			return server
+		}, nil)))
+		mux.Handle("/metrics", requireToken(metrics.Handler()))
+		mux.HandleFunc("/healthz", healthz)
+		mux.HandleFunc("/readyz", readyz)
+		mux.HandleFunc("/version", serveVersion)
+		slog.Info("MCP Server listening on HTTP", "address", cfg.HTTPAddress, "path", "/mcp", "metrics", "/metrics", "bearerToken", cfg.HTTPToken != "")
+		err = http.ListenAndServe(cfg.HTTPAddress, mux)
+	default:
+		slog.Info("MCP Server listening on stdin/stdout")
+		err = server.Run(context.Background(), &mcp.StdioTransport{})
+	}
+	if err := shutdownTracing(context.Background()); err != nil {
+		slog.Warn("Failed to flush traces", "error", err)
+	}
+	if err != nil {
+		fatal("Server stopped", err)
+	}
+}
+
+// staticTokenVerifier accepts only the configured bearer token and attributes
+// the request to user. The token does not expire, so every accepted request is
+// given an expiration in the future.
+func staticTokenVerifier(token, user string) auth.TokenVerifier {
+	return func(ctx context.Context, presented string, req *http.Request) (*auth.TokenInfo, error) {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			return nil, auth.ErrInvalidToken
+		}
+		return &auth.TokenInfo{UserID: user, Expiration: time.Now().Add(time.Hour)}, nil
+	}
+}
+
+// loadClusters creates a client for every configured context, the default
+// one first. Without contexts, a single client is created from the
+// in-cluster config or the kubeconfig.
+func loadClusters(cfg *config.Config, scheme *runtime.Scheme) ([]*tools.Cluster, error) {
+	if len(cfg.Contexts) == 0 {
+		cluster, err := loadCluster(cfg, cfg.KubeContext, scheme)
+		if err != nil {
+			return nil, err
+		}
+		return []*tools.Cluster{cluster}, nil
+	}
+
+	contexts := cfg.Contexts
+	if cfg.KubeContext != "" {
+		contexts = append([]string{cfg.KubeContext}, slices.DeleteFunc(slices.Clone(contexts), func(name string) bool {
+			return name == cfg.KubeContext
+		})...)
+	}
+
+	var clusters []*tools.Cluster
+	for _, contextName := range contexts {
+		cluster, err := loadCluster(cfg, contextName, scheme)
+		if err != nil {
+			return nil, fmt.Errorf("context '%s': %w", contextName, err)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
+// loadCluster prefers the in-cluster config unless a kubeconfig or context
+// is configured. Kubeconfig files are loaded the same way as kubectl does,
+// honoring KUBECONFIG. The cluster's namespace is the namespace of the pod or
+// of the kubeconfig context.
+func loadCluster(cfg *config.Config, contextName string, scheme *runtime.Scheme) (*tools.Cluster, error) {
+	if cfg.Kubeconfig == "" && contextName == "" {
+		restConfig, err := rest.InClusterConfig()
+		if err == nil {
+			return newCluster("in-cluster", podNamespace(), restConfig, scheme, cfg.Cache)
+		}
+		slog.Info("Not running in cluster, trying kubeconfig")
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = cfg.Kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: contextName,
+	})
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, err
+	}
+	if contextName == "" {
+		rawConfig, err := clientConfig.RawConfig()
+		if err != nil {
+			return nil, err
+		}
+		contextName = rawConfig.CurrentContext
+	}
+	return newCluster(contextName, namespace, restConfig, scheme, cfg.Cache)
+}
+
+func podNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	if namespace, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		return strings.TrimSpace(string(namespace))
 	}
+	return ""
+}
+
+func newCluster(name, namespace string, restConfig *rest.Config, scheme *runtime.Scheme, withCache bool) (*tools.Cluster, error) {
+	restConfig.Wrap(metrics.InstrumentTransport(name))
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	cluster := &tools.Cluster{
+		Name:      name,
+		Host:      restConfig.Host,
+		Namespace: namespace,
+		Client:    k8sClient,
+		Clientset: clientset,
+	}
+	if withCache {
+		cluster.Cache, err = cache.New(restConfig, cache.Options{Scheme: scheme})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cluster, nil
+}
+
+// healthz reports that the process is serving requests, without contacting
+// any cluster, so that a cluster outage does not restart the server.
+func healthz(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "ok")
+}
+
+// readyz reports whether every configured cluster is reachable and serves
+// the Shipwright Build resources.
+func readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	var body strings.Builder
+	ready := true
+	for _, cluster := range tools.CheckReadiness(ctx) {
+		if cluster.Err != nil {
+			ready = false
+			body.WriteString(fmt.Sprintf("[-]cluster %s failed: %v\n", cluster.Name, cluster.Err))
+			continue
+		}
+		body.WriteString(fmt.Sprintf("[+]cluster %s ok\n", cluster.Name))
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		slog.Warn("Readiness check failed", "details", strings.TrimSpace(body.String()))
+	}
+	fmt.Fprint(w, body.String())
+}
+
+func serveVersion(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"version":   version,
+		"goVersion": goruntime.Version(),
+	})
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
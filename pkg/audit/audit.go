@@ -0,0 +1,182 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveSuffixes are the endings of key names, lower-cased and without
+// dashes and underscores, whose values are redacted. Matching endings rather
+// than substrings keeps names such as secretKeyRef or a label key readable.
+var sensitiveSuffixes = []string{"secret", "password", "passwd", "token", "credential", "credentials", "apikey", "privatekey", "sshkey", "accesskey", "secretkey"}
+
+// Record describes one call of a mutating tool.
+type Record struct {
+	Time      time.Time      `json:"time"`
+	Tool      string         `json:"tool"`
+	Session   string         `json:"session,omitempty"`
+	Client    string         `json:"client,omitempty"`
+	User      string         `json:"user,omitempty"`
+	Cluster   string         `json:"cluster,omitempty"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+	Objects   []Object       `json:"objects,omitempty"`
+	Result    string         `json:"result"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// Object is a Kubernetes object created, updated or deleted by the call.
+type Object struct {
+	Action     string    `json:"action"`
+	APIVersion string    `json:"apiVersion,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid,omitempty"`
+}
+
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+}
+
+type jsonLinesSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLinesSink writes every record as one line of JSON to w.
+func NewJSONLinesSink(w io.Writer) Sink {
+	return &jsonLinesSink{w: w}
+}
+
+// NewFileSink appends JSON lines to the file at path.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLinesSink(file), nil
+}
+
+func (s *jsonLinesSink) Write(ctx context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+type eventSink struct {
+	clientset func(cluster string) kubernetes.Interface
+}
+
+// NewEventSink records every call as a Kubernetes Event on each object it
+// changed. Calls that did not change any object are not recorded. clientset
+// returns the clientset of the cluster named in the record.
+func NewEventSink(clientset func(cluster string) kubernetes.Interface) Sink {
+	return &eventSink{clientset: clientset}
+}
+
+// WritesEvents reports whether sink records calls as Kubernetes Events, which
+// needs permission to create Events in the namespaces of the changed objects.
+func WritesEvents(sink Sink) bool {
+	_, ok := sink.(*eventSink)
+	return ok
+}
+
+func (s *eventSink) Write(ctx context.Context, record Record) error {
+	clientset := s.clientset(record.Cluster)
+	if clientset == nil {
+		return fmt.Errorf("unknown cluster '%s'", record.Cluster)
+	}
+
+	actor := record.User
+	if actor == "" {
+		actor = record.Client
+	}
+	if record.Session != "" {
+		actor = fmt.Sprintf("%s (session %s)", actor, record.Session)
+	}
+
+	eventType := corev1.EventTypeNormal
+	if record.Result != "success" {
+		eventType = corev1.EventTypeWarning
+	}
+
+	for _, object := range record.Objects {
+		if object.Namespace == "" {
+			continue
+		}
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: object.Name + ".",
+				Namespace:    object.Namespace,
+			},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: object.APIVersion,
+				Kind:       object.Kind,
+				Namespace:  object.Namespace,
+				Name:       object.Name,
+				UID:        object.UID,
+			},
+			Reason:         "MCPToolCall",
+			Message:        fmt.Sprintf("%s %s by %s through %s: %s", strings.ToUpper(object.Action[:1])+object.Action[1:], object.Kind, actor, record.Tool, record.Result),
+			Type:           eventType,
+			Source:         corev1.EventSource{Component: "shipwright-build-mcp-server"},
+			FirstTimestamp: metav1.NewTime(record.Time),
+			LastTimestamp:  metav1.NewTime(record.Time),
+			Count:          1,
+		}
+		if _, err := clientset.CoreV1().Events(object.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Redact replaces the values of keys that look like they hold secrets, at any
+// depth of args.
+func Redact(args map[string]any) map[string]any {
+	for key, value := range args {
+		if isSensitive(key) {
+			args[key] = redacted
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			args[key] = Redact(v)
+		case []any:
+			for i, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					v[i] = Redact(m)
+				}
+			}
+		}
+	}
+	return args
+}
+
+func isSensitive(key string) bool {
+	key = strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want map[string]any
+	}{
+		{
+			name: "nothing sensitive",
+			args: map[string]any{"name": "app", "namespace": "team-a", "limit": 10.0},
+			want: map[string]any{"name": "app", "namespace": "team-a", "limit": 10.0},
+		},
+		{
+			name: "sensitive keys in any case",
+			args: map[string]any{"password": "p", "API_TOKEN": "t", "clientSecret": "s", "credentials": "c", "ssh-key": "k"},
+			want: map[string]any{"password": redacted, "API_TOKEN": redacted, "clientSecret": redacted, "credentials": redacted, "ssh-key": redacted},
+		},
+		{
+			name: "nested maps",
+			args: map[string]any{"parameters": map[string]any{"registry-password": "p", "dockerfile": "Dockerfile"}},
+			want: map[string]any{"parameters": map[string]any{"registry-password": redacted, "dockerfile": "Dockerfile"}},
+		},
+		{
+			name: "maps in lists",
+			args: map[string]any{"env": []any{map[string]any{"name": "A", "token": "t"}, "plain"}},
+			want: map[string]any{"env": []any{map[string]any{"name": "A", "token": redacted}, "plain"}},
+		},
+		{
+			name: "names that only contain a sensitive word",
+			args: map[string]any{"key": "app", "secretKeyRef": map[string]any{"name": "registry", "key": "config.json"}, "cache-key": "v1", "tokenizer": "bpe", "passwordless": true},
+			want: map[string]any{"key": "app", "secretKeyRef": map[string]any{"name": "registry", "key": "config.json"}, "cache-key": "v1", "tokenizer": "bpe", "passwordless": true},
+		},
+		{
+			name: "sensitive suffixes with separators",
+			args: map[string]any{"AWS_ACCESS_KEY": "a", "private-key": "p", "apiKey": "k", "push_secret": "s"},
+			want: map[string]any{"AWS_ACCESS_KEY": redacted, "private-key": redacted, "apiKey": redacted, "push_secret": redacted},
+		},
+		{
+			name: "sensitive key with a map value",
+			args: map[string]any{"secret": map[string]any{"name": "registry"}},
+			want: map[string]any{"secret": redacted},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Redact(test.args); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Redact() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+)
+
+const (
+	AuditSinkNone   = "none"
+	AuditSinkStderr = "stderr"
+	AuditSinkFile   = "file"
+	AuditSinkEvent  = "event"
+)
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+type Config struct {
+	DefaultNamespace    string      `json:"defaultNamespace,omitempty"`
+	DefaultStrategy     string      `json:"defaultStrategy,omitempty"`
+	DefaultStrategyKind string      `json:"defaultStrategyKind,omitempty"`
+	Kubeconfig          string      `json:"kubeconfig,omitempty"`
+	KubeContext         string      `json:"kubeContext,omitempty"`
+	Contexts            []string    `json:"contexts,omitempty"`
+	Transport           string      `json:"transport,omitempty"`
+	HTTPAddress         string      `json:"httpAddress,omitempty"`
+	HTTPToken           string      `json:"httpToken,omitempty"`
+	HTTPUser            string      `json:"httpUser,omitempty"`
+	LogLevel            string      `json:"logLevel,omitempty"`
+	LogToClient         bool        `json:"logToClient,omitempty"`
+	AllowedNamespaces   []string    `json:"allowedNamespaces,omitempty"`
+	ReadOnly            bool        `json:"readOnly,omitempty"`
+	Tools               Tools       `json:"tools,omitempty"`
+	Audit               Audit       `json:"audit,omitempty"`
+	Tracing             Tracing     `json:"tracing,omitempty"`
+	BundleRegistry      string      `json:"bundleRegistry,omitempty"`
+	BundleInsecure      bool        `json:"bundleInsecure,omitempty"`
+	LocalSource         LocalSource `json:"localSource,omitempty"`
+	Cache               bool        `json:"cache,omitempty"`
+}
+
+type Audit struct {
+	Sink string `json:"sink,omitempty"`
+	File string `json:"file,omitempty"`
+}
+
+// Tracing selects where OpenTelemetry spans are exported. An empty OTLP
+// endpoint falls back to the standard OTEL_EXPORTER_OTLP_* variables.
+type Tracing struct {
+	Exporter string `json:"exporter,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+// LocalSource limits which directories create_buildrun_from_local_source may
+// pack. The server's working directory is used when Roots is empty. The tool
+// is not registered in HTTP mode unless EnableOverHTTP is set.
+type LocalSource struct {
+	Roots          []string `json:"roots,omitempty"`
+	EnableOverHTTP bool     `json:"enableOverHTTP,omitempty"`
+}
+
+type Tools struct {
+	Enable  []string `json:"enable,omitempty"`
+	Disable []string `json:"disable,omitempty"`
+}
+
+func Default() *Config {
+	return &Config{
+		DefaultStrategyKind: string(buildv1beta1.ClusterBuildStrategyKind),
+		Transport:           TransportStdio,
+		HTTPAddress:         "127.0.0.1:8080",
+		LogLevel:            "info",
+		Audit:               Audit{Sink: AuditSinkNone},
+		Tracing:             Tracing{Exporter: TracingExporterNone},
+	}
+}
+
+// Load reads the YAML file on top of the defaults, when file is not
+// empty, and then applies the environment overrides. The result is not
+// validated yet, so that flags can still be applied by the caller.
+func Load(file string) (*Config, error) {
+	cfg := Default()
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+			return nil, fmt.Errorf("invalid config file '%s': %w", file, err)
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	texts := map[string]*string{
+		"SHIPWRIGHT_MCP_DEFAULT_NAMESPACE":     &c.DefaultNamespace,
+		"SHIPWRIGHT_MCP_DEFAULT_STRATEGY":      &c.DefaultStrategy,
+		"SHIPWRIGHT_MCP_DEFAULT_STRATEGY_KIND": &c.DefaultStrategyKind,
+		"SHIPWRIGHT_MCP_KUBECONFIG":            &c.Kubeconfig,
+		"SHIPWRIGHT_MCP_KUBE_CONTEXT":          &c.KubeContext,
+		"SHIPWRIGHT_MCP_TRANSPORT":             &c.Transport,
+		"SHIPWRIGHT_MCP_HTTP_ADDRESS":          &c.HTTPAddress,
+		"SHIPWRIGHT_MCP_HTTP_TOKEN":            &c.HTTPToken,
+		"SHIPWRIGHT_MCP_HTTP_USER":             &c.HTTPUser,
+		"SHIPWRIGHT_MCP_LOG_LEVEL":             &c.LogLevel,
+		"SHIPWRIGHT_MCP_AUDIT_SINK":            &c.Audit.Sink,
+		"SHIPWRIGHT_MCP_AUDIT_FILE":            &c.Audit.File,
+		"SHIPWRIGHT_MCP_TRACING_EXPORTER":      &c.Tracing.Exporter,
+		"SHIPWRIGHT_MCP_TRACING_ENDPOINT":      &c.Tracing.Endpoint,
+		"SHIPWRIGHT_BUNDLE_REGISTRY":           &c.BundleRegistry,
+	}
+	for name, field := range texts {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = value
+		}
+	}
+
+	lists := map[string]*[]string{
+		"SHIPWRIGHT_MCP_CONTEXTS":           &c.Contexts,
+		"SHIPWRIGHT_MCP_ALLOWED_NAMESPACES": &c.AllowedNamespaces,
+		"SHIPWRIGHT_MCP_ENABLE_TOOLS":       &c.Tools.Enable,
+		"SHIPWRIGHT_MCP_DISABLE_TOOLS":      &c.Tools.Disable,
+		"SHIPWRIGHT_MCP_LOCAL_SOURCE_ROOTS": &c.LocalSource.Roots,
+	}
+	for name, field := range lists {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = SplitList(value)
+		}
+	}
+
+	bools := map[string]*bool{
+		"SHIPWRIGHT_MCP_READ_ONLY":              &c.ReadOnly,
+		"SHIPWRIGHT_MCP_LOG_TO_CLIENT":          &c.LogToClient,
+		"SHIPWRIGHT_MCP_TRACING_INSECURE":       &c.Tracing.Insecure,
+		"SHIPWRIGHT_BUNDLE_INSECURE":            &c.BundleInsecure,
+		"SHIPWRIGHT_MCP_CACHE":                  &c.Cache,
+		"SHIPWRIGHT_MCP_LOCAL_SOURCE_OVER_HTTP": &c.LocalSource.EnableOverHTTP,
+	}
+	for name, field := range bools {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s' for %s, expected true or false", value, name)
+			}
+			*field = parsed
+		}
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var problems []string
+
+	if errs := validation.IsDNS1123Label(c.DefaultNamespace); c.DefaultNamespace != "" && len(errs) > 0 {
+		problems = append(problems, fmt.Sprintf("defaultNamespace '%s' is not a valid namespace name: %s", c.DefaultNamespace, strings.Join(errs, ", ")))
+	}
+	switch buildv1beta1.BuildStrategyKind(c.DefaultStrategyKind) {
+	case buildv1beta1.NamespacedBuildStrategyKind, buildv1beta1.ClusterBuildStrategyKind:
+	default:
+		problems = append(problems, fmt.Sprintf("defaultStrategyKind must be 'BuildStrategy' or 'ClusterBuildStrategy', got '%s'", c.DefaultStrategyKind))
+	}
+	if c.KubeContext != "" && len(c.Contexts) > 0 && !slices.Contains(c.Contexts, c.KubeContext) {
+		problems = append(problems, fmt.Sprintf("kubeContext '%s' must be one of contexts", c.KubeContext))
+	}
+	switch c.Transport {
+	case TransportStdio:
+	case TransportHTTP:
+		if c.HTTPAddress == "" {
+			problems = append(problems, "httpAddress is required when transport is 'http'")
+		} else if c.HTTPToken == "" && !loopbackAddress(c.HTTPAddress) {
+			problems = append(problems, fmt.Sprintf("httpToken is required when httpAddress '%s' is not a loopback address", c.HTTPAddress))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("transport must be 'stdio' or 'http', got '%s'", c.Transport))
+	}
+	switch c.Audit.Sink {
+	case AuditSinkNone, AuditSinkStderr, AuditSinkEvent:
+	case AuditSinkFile:
+		if c.Audit.File == "" {
+			problems = append(problems, "audit.file is required when audit.sink is 'file'")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("audit.sink must be 'none', 'stderr', 'file' or 'event', got '%s'", c.Audit.Sink))
+	}
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterOTLP, TracingExporterStdout:
+	default:
+		problems = append(problems, fmt.Sprintf("tracing.exporter must be 'none', 'otlp' or 'stdout', got '%s'", c.Tracing.Exporter))
+	}
+	for _, root := range c.LocalSource.Roots {
+		if !filepath.IsAbs(root) {
+			problems = append(problems, fmt.Sprintf("localSource.roots must be absolute paths, got '%s'", root))
+		}
+	}
+	if _, err := c.Level(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	defaultAllowed := len(c.AllowedNamespaces) == 0 || c.DefaultNamespace == ""
+	for _, pattern := range c.AllowedNamespaces {
+		matched, err := path.Match(pattern, c.DefaultNamespace)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("allowedNamespaces pattern '%s' is invalid: %v", pattern, err))
+		}
+		defaultAllowed = defaultAllowed || matched
+	}
+	if !defaultAllowed {
+		problems = append(problems, fmt.Sprintf("defaultNamespace '%s' does not match any of allowedNamespaces", c.DefaultNamespace))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func (c *Config) Level() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return level, fmt.Errorf("logLevel must be 'debug', 'info', 'warn' or 'error', got '%s'", c.LogLevel)
+	}
+	return level, nil
+}
+
+// TokenUser is the identity recorded for requests authenticated with
+// httpToken: httpUser when set, otherwise a name derived from a hash of the
+// token, so that calls made with different tokens can be told apart without
+// the token appearing in the audit log.
+func (c *Config) TokenUser() string {
+	if c.HTTPUser != "" {
+		return c.HTTPUser
+	}
+	sum := sha256.Sum256([]byte(c.HTTPToken))
+	return "token-" + hex.EncodeToString(sum[:])[:12]
+}
+
+// loopbackAddress reports whether address only accepts connections from the
+// local host. An empty host listens on every interface.
+func loopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func SplitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
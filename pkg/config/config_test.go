@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		problem string
+	}{
+		{"defaults", func(c *Config) {}, ""},
+		{"invalid namespace", func(c *Config) { c.DefaultNamespace = "Team_A" }, "defaultNamespace 'Team_A' is not a valid namespace name"},
+		{"invalid strategy kind", func(c *Config) { c.DefaultStrategyKind = "Strategy" }, "defaultStrategyKind must be"},
+		{"unknown context", func(c *Config) { c.KubeContext = "prod"; c.Contexts = []string{"dev"} }, "kubeContext 'prod' must be one of contexts"},
+		{"unknown transport", func(c *Config) { c.Transport = "grpc" }, "transport must be 'stdio' or 'http'"},
+		{"http on loopback", func(c *Config) { c.Transport = TransportHTTP }, ""},
+		{"http on localhost", func(c *Config) { c.Transport = TransportHTTP; c.HTTPAddress = "localhost:9090" }, ""},
+		{"http on all interfaces", func(c *Config) { c.Transport = TransportHTTP; c.HTTPAddress = ":8080" }, "httpToken is required when httpAddress ':8080' is not a loopback address"},
+		{"http with token", func(c *Config) { c.Transport = TransportHTTP; c.HTTPAddress = "0.0.0.0:8080"; c.HTTPToken = "secret" }, ""},
+		{"http without address", func(c *Config) { c.Transport = TransportHTTP; c.HTTPAddress = "" }, "httpAddress is required"},
+		{"audit file without path", func(c *Config) { c.Audit.Sink = AuditSinkFile }, "audit.file is required"},
+		{"unknown audit sink", func(c *Config) { c.Audit.Sink = "syslog" }, "audit.sink must be"},
+		{"unknown tracing exporter", func(c *Config) { c.Tracing.Exporter = "jaeger" }, "tracing.exporter must be"},
+		{"invalid log level", func(c *Config) { c.LogLevel = "trace" }, "logLevel must be"},
+		{"relative local source root", func(c *Config) { c.LocalSource.Roots = []string{"src"} }, "localSource.roots must be absolute paths, got 'src'"},
+		{"invalid namespace pattern", func(c *Config) { c.AllowedNamespaces = []string{"team-["} }, "allowedNamespaces pattern 'team-[' is invalid"},
+		{"default namespace not allowed", func(c *Config) { c.DefaultNamespace = "prod"; c.AllowedNamespaces = []string{"team-*"} }, "defaultNamespace 'prod' does not match any of allowedNamespaces"},
+		{"default namespace allowed", func(c *Config) { c.DefaultNamespace = "team-a"; c.AllowedNamespaces = []string{"team-*"} }, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := Default()
+			test.modify(cfg)
+			err := cfg.Validate()
+			switch {
+			case test.problem == "" && err != nil:
+				t.Errorf("Validate() = %v, want no error", err)
+			case test.problem != "" && (err == nil || !strings.Contains(err.Error(), test.problem)):
+				t.Errorf("Validate() = %v, want an error containing %q", err, test.problem)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default()
+	cfg.Transport = "grpc"
+	cfg.LogLevel = "trace"
+	err := cfg.Validate()
+	if err == nil || strings.Count(err.Error(), "; ") != 1 {
+		t.Errorf("Validate() = %v, want two problems", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	content := "defaultNamespace: team-a\ntransport: http\ncontexts: [dev, prod]\ntools:\n  disable: [create_buildrun]\n"
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		check func(c *Config) bool
+	}{
+		{"file values", nil, func(c *Config) bool {
+			return c.DefaultNamespace == "team-a" && c.Transport == TransportHTTP && slices.Equal(c.Contexts, []string{"dev", "prod"})
+		}},
+		{"defaults kept", nil, func(c *Config) bool {
+			return c.HTTPAddress == "127.0.0.1:8080" && c.LogLevel == "info" && c.Audit.Sink == AuditSinkNone
+		}},
+		{"text override", map[string]string{"SHIPWRIGHT_MCP_DEFAULT_NAMESPACE": "team-b"}, func(c *Config) bool {
+			return c.DefaultNamespace == "team-b"
+		}},
+		{"list override", map[string]string{"SHIPWRIGHT_MCP_DISABLE_TOOLS": "delete_*, create_build ,"}, func(c *Config) bool {
+			return slices.Equal(c.Tools.Disable, []string{"delete_*", "create_build"})
+		}},
+		{"bool override", map[string]string{"SHIPWRIGHT_MCP_READ_ONLY": "true", "SHIPWRIGHT_MCP_LOCAL_SOURCE_OVER_HTTP": "1"}, func(c *Config) bool {
+			return c.ReadOnly && c.LocalSource.EnableOverHTTP
+		}},
+		{"token override", map[string]string{"SHIPWRIGHT_MCP_HTTP_TOKEN": "secret", "SHIPWRIGHT_MCP_HTTP_USER": "ci"}, func(c *Config) bool {
+			return c.HTTPToken == "secret" && c.HTTPUser == "ci"
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			cfg, err := Load(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !test.check(cfg) {
+				t.Errorf("unexpected configuration: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestTokenUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		user  string
+		want  string
+	}{
+		{"configured user", "secret", "ci", "ci"},
+		{"derived from the token", "secret", "", "token-2bb80d537b1d"},
+		{"other token", "other", "", "token-d9298a10d1b0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := &Config{HTTPToken: test.token, HTTPUser: test.user}
+			if got := cfg.TokenUser(); got != test.want {
+				t.Errorf("TokenUser() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("defaultNamspace: team-a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(file); err == nil {
+		t.Error("Load() accepted an unknown field")
+	}
+
+	t.Setenv("SHIPWRIGHT_MCP_CACHE", "yes")
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "SHIPWRIGHT_MCP_CACHE") {
+		t.Errorf("Load() = %v, want an error naming SHIPWRIGHT_MCP_CACHE", err)
+	}
+}
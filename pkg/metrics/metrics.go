@@ -0,0 +1,214 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "shipwright_mcp"
+
+// Error classes used for the tool_errors_total metric.
+const (
+	ErrorClassInvalidRequest = "invalid_request"
+	ErrorClassForbidden      = "forbidden"
+	ErrorClassNotFound       = "not_found"
+	ErrorClassConflict       = "conflict"
+	ErrorClassKubernetes     = "kubernetes_error"
+	ErrorClassUnavailable    = "kubernetes_unavailable"
+	ErrorClassInternal       = "internal"
+)
+
+var (
+	ToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Number of MCP tool calls.",
+	}, []string{"tool"})
+
+	ToolErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_errors_total",
+		Help:      "Number of MCP tool calls that returned an error, by error class.",
+	}, []string{"tool", "class"})
+
+	ToolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_duration_seconds",
+		Help:      "Duration of MCP tool calls.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+	}, []string{"tool"})
+
+	KubernetesRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "kubernetes_request_duration_seconds",
+		Help:      "Duration of requests to the Kubernetes API server.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"cluster", "verb", "resource", "code"})
+
+	ActiveSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "Number of initialized MCP sessions that are still open.",
+	})
+
+	BuildRunsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "buildruns_created_total",
+		Help:      "Number of BuildRuns created through the server.",
+	}, []string{"namespace", "strategy"})
+)
+
+var Registry = prometheus.NewRegistry()
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ToolCalls,
+		ToolErrors,
+		ToolDuration,
+		KubernetesRequestDuration,
+		ActiveSessions,
+		BuildRunsCreated,
+	)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// CallStats collects the outcome of the Kubernetes requests made during one
+// tool call, so that a failed call can be assigned an error class.
+type CallStats struct {
+	mu    sync.Mutex
+	codes []int
+}
+
+type callStatsKey struct{}
+
+func WithCallStats(ctx context.Context, stats *CallStats) context.Context {
+	return context.WithValue(ctx, callStatsKey{}, stats)
+}
+
+func (s *CallStats) add(code int) {
+	s.mu.Lock()
+	s.codes = append(s.codes, code)
+	s.mu.Unlock()
+}
+
+// ErrorClass classifies a failed call by the last failed Kubernetes request
+// it made. Calls that failed without a failed request were rejected before
+// reaching the API server.
+func (s *CallStats) ErrorClass() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.codes) - 1; i >= 0; i-- {
+		switch code := s.codes[i]; {
+		case code == 0:
+			return ErrorClassUnavailable
+		case code == http.StatusForbidden || code == http.StatusUnauthorized:
+			return ErrorClassForbidden
+		case code == http.StatusNotFound:
+			return ErrorClassNotFound
+		case code == http.StatusConflict:
+			return ErrorClassConflict
+		case code >= 500:
+			return ErrorClassUnavailable
+		case code >= 400:
+			return ErrorClassKubernetes
+		}
+	}
+	return ErrorClassInvalidRequest
+}
+
+type instrumentedTransport struct {
+	cluster string
+	next    http.RoundTripper
+}
+
+// InstrumentTransport returns a wrapper for the transport of a cluster's
+// rest.Config that observes the duration of every Kubernetes API request.
+// Watches, such as those of the informer cache, stay open for minutes and are
+// not observed, so that they do not skew the latency of other requests.
+func InstrumentTransport(cluster string) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &instrumentedTransport{cluster: cluster, next: next}
+	}
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, resource := requestVerbAndResource(req)
+	if verb == "watch" {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	code := 0
+	if err == nil {
+		code = resp.StatusCode
+	}
+	KubernetesRequestDuration.WithLabelValues(t.cluster, verb, resource, strconv.Itoa(code)).Observe(time.Since(start).Seconds())
+	if stats, ok := req.Context().Value(callStatsKey{}).(*CallStats); ok {
+		stats.add(code)
+	}
+	return resp, err
+}
+
+// requestVerbAndResource derives the Kubernetes verb and resource from an API
+// request path such as /apis/shipwright.io/v1beta1/namespaces/ns/buildruns/name.
+func requestVerbAndResource(req *http.Request) (string, string) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(segments) >= 2 && segments[0] == "api":
+		segments = segments[2:]
+	case len(segments) >= 3 && segments[0] == "apis":
+		segments = segments[3:]
+	default:
+		return strings.ToLower(req.Method), "discovery"
+	}
+	if len(segments) > 2 && segments[0] == "namespaces" {
+		segments = segments[2:]
+	}
+	if len(segments) == 0 {
+		return strings.ToLower(req.Method), "discovery"
+	}
+
+	resource := segments[0]
+	if len(segments) >= 3 {
+		resource += "/" + segments[2]
+	}
+	named := len(segments) >= 2
+
+	switch req.Method {
+	case http.MethodGet:
+		if watch, _ := strconv.ParseBool(req.URL.Query().Get("watch")); watch {
+			return "watch", resource
+		}
+		if named {
+			return "get", resource
+		}
+		return "list", resource
+	case http.MethodPost:
+		return "create", resource
+	case http.MethodPut:
+		return "update", resource
+	case http.MethodPatch:
+		return "patch", resource
+	case http.MethodDelete:
+		if named {
+			return "delete", resource
+		}
+		return "deletecollection", resource
+	}
+	return strings.ToLower(req.Method), resource
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestVerbAndResource(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		verb     string
+		resource string
+	}{
+		{http.MethodGet, "/apis/shipwright.io/v1beta1/namespaces/team-a/buildruns", "list", "buildruns"},
+		{http.MethodGet, "/apis/shipwright.io/v1beta1/namespaces/team-a/buildruns/run-1", "get", "buildruns"},
+		{http.MethodGet, "/apis/shipwright.io/v1beta1/buildruns", "list", "buildruns"},
+		{http.MethodGet, "/apis/shipwright.io/v1beta1/clusterbuildstrategies/buildah", "get", "clusterbuildstrategies"},
+		{http.MethodGet, "/apis/shipwright.io/v1beta1/namespaces/team-a/builds?watch=true", "watch", "builds"},
+		{http.MethodGet, "/api/v1/namespaces/team-a/pods/pod-1/log", "get", "pods/log"},
+		{http.MethodGet, "/api/v1/namespaces/team-a", "get", "namespaces"},
+		{http.MethodGet, "/api/v1/namespaces", "list", "namespaces"},
+		{http.MethodPost, "/apis/shipwright.io/v1beta1/namespaces/team-a/buildruns", "create", "buildruns"},
+		{http.MethodPost, "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews", "create", "selfsubjectaccessreviews"},
+		{http.MethodPut, "/apis/shipwright.io/v1beta1/namespaces/team-a/builds/app", "update", "builds"},
+		{http.MethodPatch, "/apis/shipwright.io/v1beta1/namespaces/team-a/builds/app", "patch", "builds"},
+		{http.MethodDelete, "/apis/shipwright.io/v1beta1/namespaces/team-a/builds/app", "delete", "builds"},
+		{http.MethodDelete, "/apis/shipwright.io/v1beta1/namespaces/team-a/buildruns", "deletecollection", "buildruns"},
+		{http.MethodGet, "/apis/shipwright.io/v1beta1", "get", "discovery"},
+		{http.MethodGet, "/version", "get", "discovery"},
+	}
+	for _, test := range tests {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			verb, resource := requestVerbAndResource(httptest.NewRequest(test.method, test.path, nil))
+			if verb != test.verb || resource != test.resource {
+				t.Errorf("requestVerbAndResource() = %s %s, want %s %s", verb, resource, test.verb, test.resource)
+			}
+		})
+	}
+}
+
+type stubTransport struct{}
+
+func (stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+}
+
+func TestInstrumentTransportSkipsWatches(t *testing.T) {
+	transport := InstrumentTransport("skip-watches")(stubTransport{})
+	for _, path := range []string{
+		"/apis/shipwright.io/v1beta1/buildruns?watch=true",
+		"/apis/shipwright.io/v1beta1/namespaces/team-a/builds?watch=1&resourceVersion=10",
+		"/apis/shipwright.io/v1beta1/namespaces/team-a/builds",
+	} {
+		if _, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := testutil.CollectAndCount(KubernetesRequestDuration.MustCurryWith(prometheus.Labels{"cluster": "skip-watches"})); got != 1 {
+		t.Errorf("observed %d request series, want only the list", got)
+	}
+}
@@ -0,0 +1,135 @@
+package models
+
+// NamespacedParams is implemented by the params of every tool, so that the
+// namespaces a call works in are declared next to the fields that name them
+// and the server's namespace policy can be enforced before the tool runs.
+type NamespacedParams interface {
+	// RequestedNamespaces returns the namespaces the call names, and whether it
+	// also works in the default namespace because it names none.
+	RequestedNamespaces() (namespaces []string, defaultNamespace bool)
+}
+
+func singleNamespace(namespace string) ([]string, bool) {
+	if namespace == "" {
+		return nil, true
+	}
+	return []string{namespace}, false
+}
+
+// multipleNamespaces is the scope of list tools, which ignore the default
+// namespace when listing several namespaces or all of them.
+func multipleNamespaces(namespace string, namespaces []string, allNamespaces bool) ([]string, bool) {
+	if len(namespaces) == 0 && !allNamespaces {
+		return singleNamespace(namespace)
+	}
+	if namespace != "" {
+		return append([]string{namespace}, namespaces...), false
+	}
+	return namespaces, false
+}
+
+func (p ListBuildsParams) RequestedNamespaces() ([]string, bool) {
+	return multipleNamespaces(p.Namespace, p.Namespaces, p.AllNamespaces)
+}
+
+func (p ListBuildRunsParams) RequestedNamespaces() ([]string, bool) {
+	return multipleNamespaces(p.Namespace, p.Namespaces, p.AllNamespaces)
+}
+
+func (p ListBuildStrategiesParams) RequestedNamespaces() ([]string, bool) {
+	return multipleNamespaces(p.Namespace, p.Namespaces, p.AllNamespaces)
+}
+
+func (p GetBuildParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p CreateBuildParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p GetBuildRunParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p CreateBuildRunParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p RestartBuildRunParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p DeleteBuildParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p DeleteBuildRunParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p DiagnoseBuildRunParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p GetBuildStatsParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p GetBuildHistoryParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p CreateBuildRunFromLocalSourceParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+// RequestedNamespaces is empty for ClusterBuildStrategies, which are
+// cluster-scoped and exported regardless of namespace.
+func (p ExportResourceParams) RequestedNamespaces() ([]string, bool) {
+	if p.Kind == "ClusterBuildStrategy" {
+		return nil, false
+	}
+	return singleNamespace(p.Namespace)
+}
+
+func (p CheckClusterParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p CheckPermissionsParams) RequestedNamespaces() ([]string, bool) {
+	return singleNamespace(p.Namespace)
+}
+
+func (p CopyBuildParams) RequestedNamespaces() ([]string, bool) {
+	namespaces, defaultNamespace := singleNamespace(p.Namespace)
+	if p.TargetNamespace != "" {
+		namespaces = append(namespaces, p.TargetNamespace)
+	}
+	return namespaces, defaultNamespace
+}
+
+func (p DiffBuildsParams) RequestedNamespaces() ([]string, bool) {
+	namespaces, defaultNamespace := singleNamespace(p.Namespace)
+	if p.OtherNamespace != "" {
+		namespaces = append(namespaces, p.OtherNamespace)
+	}
+	return namespaces, defaultNamespace
+}
+
+func (p ListClusterBuildStrategiesParams) RequestedNamespaces() ([]string, bool) {
+	return nil, false
+}
+
+func (p ListClustersParams) RequestedNamespaces() ([]string, bool) {
+	return nil, false
+}
+
+// RequestedNamespaces does not include the default namespace, so that the
+// session default can always be cleared.
+func (p SetDefaultNamespaceParams) RequestedNamespaces() ([]string, bool) {
+	if p.Namespace == "" {
+		return nil, false
+	}
+	return []string{p.Namespace}, false
+}
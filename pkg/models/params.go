@@ -1,17 +1,35 @@
 package models
 
+// ClusterParams is embedded in the params of every tool that talks to a
+// cluster and selects one of the configured kubeconfig contexts.
+type ClusterParams struct {
+	Context string `json:"context,omitempty"`
+}
+
+func (p ClusterParams) ClusterContext() string {
+	return p.Context
+}
+
 type ListBuildsParams struct {
-	Namespace     string `json:"namespace"`
-	Prefix        string `json:"prefix,omitempty"`
-	LabelSelector string `json:"label-selector,omitempty"`
+	ClusterParams
+
+	Namespace     string   `json:"namespace,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	AllNamespaces bool     `json:"all-namespaces,omitempty"`
+	Prefix        string   `json:"prefix,omitempty"`
+	LabelSelector string   `json:"label-selector,omitempty"`
 }
 
 type GetBuildParams struct {
+	ClusterParams
+
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
 }
 
 type CreateBuildParams struct {
+	ClusterParams
+
 	Name         string            `json:"name"`
 	Namespace    string            `json:"namespace,omitempty"`
 	SourceType   string            `json:"source-type"`
@@ -26,17 +44,34 @@ type CreateBuildParams struct {
 }
 
 type ListBuildRunsParams struct {
-	Namespace     string `json:"namespace"`
-	Prefix        string `json:"prefix,omitempty"`
-	LabelSelector string `json:"label-selector,omitempty"`
+	ClusterParams
+
+	Namespace     string   `json:"namespace,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	AllNamespaces bool     `json:"all-namespaces,omitempty"`
+	Prefix        string   `json:"prefix,omitempty"`
+	LabelSelector string   `json:"label-selector,omitempty"`
+	Status        string   `json:"status,omitempty"`
+	BuildName     string   `json:"build-name,omitempty"`
+	FailureReason string   `json:"failure-reason,omitempty"`
+	CreatedAfter  string   `json:"created-after,omitempty"`
+	CreatedBefore string   `json:"created-before,omitempty"`
+	SortBy        string   `json:"sort-by,omitempty"`
+	SortOrder     string   `json:"sort-order,omitempty"`
+	Limit         int64    `json:"limit,omitempty"`
+	Continue      string   `json:"continue,omitempty"`
 }
 
 type GetBuildRunParams struct {
+	ClusterParams
+
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
 }
 
 type CreateBuildRunParams struct {
+	ClusterParams
+
 	Name      string `json:"name,omitempty"`
 	Namespace string `json:"namespace,omitempty"`
 	BuildName string `json:"build-name,omitempty"`
@@ -54,27 +89,135 @@ type CreateBuildRunParams struct {
 }
 
 type RestartBuildRunParams struct {
+	ClusterParams
+
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
 }
 
 type DeleteBuildParams struct {
+	ClusterParams
+
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
+	Cascade   string `json:"cascade,omitempty"`
+	Confirm   bool   `json:"confirm,omitempty"`
 }
 
 type DeleteBuildRunParams struct {
+	ClusterParams
+
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
+	Confirm   bool   `json:"confirm,omitempty"`
 }
 
 type ListBuildStrategiesParams struct {
-	Namespace     string `json:"namespace"`
-	Prefix        string `json:"prefix,omitempty"`
-	LabelSelector string `json:"label-selector,omitempty"`
+	ClusterParams
+
+	Namespace     string   `json:"namespace,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	AllNamespaces bool     `json:"all-namespaces,omitempty"`
+	Prefix        string   `json:"prefix,omitempty"`
+	LabelSelector string   `json:"label-selector,omitempty"`
 }
 
 type ListClusterBuildStrategiesParams struct {
+	ClusterParams
+
 	Prefix        string `json:"prefix,omitempty"`
 	LabelSelector string `json:"label-selector,omitempty"`
 }
+
+type DiagnoseBuildRunParams struct {
+	ClusterParams
+
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	TailLines int64  `json:"tail-lines,omitempty"`
+}
+
+type GetBuildStatsParams struct {
+	ClusterParams
+
+	Namespace string `json:"namespace,omitempty"`
+	BuildName string `json:"build-name,omitempty"`
+}
+
+type GetBuildHistoryParams struct {
+	ClusterParams
+
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Limit     int    `json:"limit,omitempty"`
+}
+
+type CreateBuildRunFromLocalSourceParams struct {
+	ClusterParams
+
+	Name        string `json:"name,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Directory   string `json:"directory"`
+	BuildName   string `json:"build-name,omitempty"`
+	BundleImage string `json:"bundle-image,omitempty"`
+
+	ContextDir     string            `json:"context-dir,omitempty"`
+	Strategy       string            `json:"strategy,omitempty"`
+	StrategyKind   string            `json:"strategy-kind,omitempty"`
+	OutputImage    string            `json:"output-image,omitempty"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	Timeout        string            `json:"timeout,omitempty"`
+	ServiceAccount string            `json:"service-account,omitempty"`
+}
+
+type ExportResourceParams struct {
+	ClusterParams
+
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	AsBuild   bool   `json:"as-build,omitempty"`
+	BuildName string `json:"build-name,omitempty"`
+}
+
+type CopyBuildParams struct {
+	ClusterParams
+
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace,omitempty"`
+	TargetName      string            `json:"target-name,omitempty"`
+	TargetNamespace string            `json:"target-namespace,omitempty"`
+	Revision        string            `json:"revision,omitempty"`
+	OutputImage     string            `json:"output-image,omitempty"`
+	Parameters      map[string]string `json:"parameters,omitempty"`
+}
+
+type DiffBuildsParams struct {
+	ClusterParams
+
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace,omitempty"`
+	OtherName      string `json:"other-name,omitempty"`
+	OtherNamespace string `json:"other-namespace,omitempty"`
+	Manifest       string `json:"manifest,omitempty"`
+}
+
+type ListClustersParams struct{}
+
+type CheckClusterParams struct {
+	ClusterParams
+
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type CheckPermissionsParams struct {
+	ClusterParams
+
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type SetDefaultNamespaceParams struct {
+	ClusterParams
+
+	Namespace string `json:"namespace,omitempty"`
+}
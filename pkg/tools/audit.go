@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/audit"
+)
+
+const maxAuditMessageLength = 500
+
+var auditSink audit.Sink
+
+func SetAuditSink(sink audit.Sink) {
+	auditSink = sink
+}
+
+// ClusterClientset returns the clientset of the named cluster, or nil.
+func ClusterClientset(name string) kubernetes.Interface {
+	if cluster, ok := clusters[name]; ok {
+		return cluster.Clientset
+	}
+	return nil
+}
+
+// recordingClient remembers every object the wrapped client creates,
+// updates, patches or deletes, so that tool calls can be audited without the
+// handlers reporting what they changed.
+type recordingClient struct {
+	client.Client
+
+	mu      sync.Mutex
+	objects []audit.Object
+}
+
+func (c *recordingClient) record(action string, obj client.Object) {
+	object := audit.Object{
+		Action:    action,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       obj.GetUID(),
+	}
+	if gvk, err := c.Client.GroupVersionKindFor(obj); err == nil {
+		object.APIVersion = gvk.GroupVersion().String()
+		object.Kind = gvk.Kind
+	}
+	c.mu.Lock()
+	c.objects = append(c.objects, object)
+	c.mu.Unlock()
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if err == nil {
+		c.record("created", obj)
+	}
+	return err
+}
+
+func (c *recordingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil {
+		c.record("updated", obj)
+	}
+	return err
+}
+
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	if err == nil {
+		c.record("patched", obj)
+	}
+	return err
+}
+
+func (c *recordingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	if err == nil {
+		c.record("deleted", obj)
+	}
+	return err
+}
+
+// withAudit writes an audit record for every call of a mutating tool to the
+// configured sink, including the objects the call changed.
+func withAudit[In any](name string, handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		if auditSink == nil {
+			return handler(ctx, req, args)
+		}
+
+		cluster := *currentCluster(ctx)
+		recorder := &recordingClient{Client: cluster.Client}
+		cluster.Client = recorder
+
+		record := audit.Record{
+			Time:    time.Now().UTC(),
+			Tool:    name,
+			Cluster: cluster.Name,
+		}
+		if req != nil && req.Session != nil {
+			record.Session = req.Session.ID()
+			if initializeParams := req.Session.InitializeParams(); initializeParams != nil && initializeParams.ClientInfo != nil {
+				record.Client = initializeParams.ClientInfo.Name
+			}
+		}
+		if req != nil && req.Extra != nil && req.Extra.TokenInfo != nil {
+			record.User = req.Extra.TokenInfo.UserID
+		}
+		if content, err := json.Marshal(args); err == nil {
+			var arguments map[string]any
+			if json.Unmarshal(content, &arguments) == nil {
+				record.Arguments = audit.Redact(arguments)
+			}
+		}
+
+		result, out, err := handler(withCluster(ctx, &cluster), req, args)
+
+		record.Objects = recorder.objects
+		switch {
+		case err != nil:
+			record.Result = "error"
+			record.Message = err.Error()
+		case result != nil && result.IsError:
+			record.Result = "error"
+		default:
+			record.Result = "success"
+		}
+		if record.Message == "" && result != nil && len(result.Content) > 0 {
+			if text, ok := result.Content[0].(*mcp.TextContent); ok {
+				record.Message = text.Text
+			}
+		}
+		if len(record.Message) > maxAuditMessageLength {
+			record.Message = record.Message[:maxAuditMessageLength] + "..."
+		}
+
+		if err := auditSink.Write(context.WithoutCancel(ctx), record); err != nil {
+			toolLogger(ctx).Error("Failed to write audit record", "error", err)
+		}
+		return result, out, err
+	}
+}
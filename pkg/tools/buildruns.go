@@ -3,67 +3,264 @@ package tools
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/shipwright-io/build/server/pkg/models"
 )
 
-func ListBuildRuns(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.ListBuildRunsParams]) (*mcp.CallToolResultFor[any], error) {
-	buildRunList := &buildv1beta1.BuildRunList{}
+var buildRunStatuses = []string{"Succeeded", "Failed", "Running", "Pending", "Canceled"}
+
+func buildRunStatus(buildRun *buildv1beta1.BuildRun) string {
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+	switch {
+	case condition != nil && condition.Reason == buildv1beta1.BuildRunStateCancel:
+		return "Canceled"
+	case condition == nil:
+		return "Pending"
+	case condition.Status == corev1.ConditionTrue:
+		return "Succeeded"
+	case condition.Status == corev1.ConditionFalse:
+		return "Failed"
+	case buildRun.HasStarted():
+		return "Running"
+	}
+	return "Pending"
+}
+
+func buildRunFailureReason(buildRun *buildv1beta1.BuildRun) string {
+	if buildRun.Status.FailureDetails != nil && buildRun.Status.FailureDetails.Reason != "" {
+		return buildRun.Status.FailureDetails.Reason
+	}
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+	if condition != nil && condition.Status == corev1.ConditionFalse {
+		return condition.Reason
+	}
+	return ""
+}
 
-	listOpts := []client.ListOption{
-		client.InNamespace(params.Arguments.Namespace),
+func sortBuildRuns(buildRuns []buildv1beta1.BuildRun, sortBy string, descending bool) {
+	timestamp := func(buildRun *buildv1beta1.BuildRun) time.Time {
+		if sortBy == "completion" {
+			if buildRun.Status.CompletionTime == nil {
+				return time.Time{}
+			}
+			return buildRun.Status.CompletionTime.Time
+		}
+		return buildRun.CreationTimestamp.Time
 	}
+	sort.SliceStable(buildRuns, func(i, j int) bool {
+		if descending {
+			return timestamp(&buildRuns[i]).After(timestamp(&buildRuns[j]))
+		}
+		return timestamp(&buildRuns[i]).Before(timestamp(&buildRuns[j]))
+	})
+}
 
-	if params.Arguments.LabelSelector != "" {
-		selector, err := metav1.ParseToLabelSelector(params.Arguments.LabelSelector)
+func flattenBuildRunLists(buildRunLists []*buildv1beta1.BuildRunList) []buildv1beta1.BuildRun {
+	var buildRuns []buildv1beta1.BuildRun
+	for _, buildRunList := range buildRunLists {
+		buildRuns = append(buildRuns, buildRunList.Items...)
+	}
+	return buildRuns
+}
+
+func ListBuildRuns(ctx context.Context, req *mcp.CallToolRequest, args models.ListBuildRunsParams) (*mcp.CallToolResult, any, error) {
+	scope := namespaceScope{
+		namespace:     args.Namespace,
+		namespaces:    args.Namespaces,
+		allNamespaces: args.AllNamespaces,
+	}
+	if err := scope.validate(); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid namespace scope: %v", err)}},
+		}, nil, nil
+	}
+	if !scope.multiple() {
+		scope.namespace = resolveNamespace(ctx, req, scope.namespace)
+	}
+	if (args.Limit != 0 || args.Continue != "") && scope.multiple() {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Limit and continue are not supported with namespaces or all-namespaces, list a single namespace to paginate"}},
+		}, nil, nil
+	}
+
+	var listOpts []client.ListOption
+
+	selectorObj := labels.Everything()
+	if args.LabelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(args.LabelSelector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
-		selectorObj, err := metav1.LabelSelectorAsSelector(selector)
+		selectorObj, err = metav1.LabelSelectorAsSelector(selector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
+	}
+	if args.BuildName != "" {
+		requirement, err := labels.NewRequirement(buildv1beta1.LabelBuild, selection.Equals, []string{args.BuildName})
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid build name: %v", err)}},
+			}, nil, nil
+		}
+		selectorObj = selectorObj.Add(*requirement)
+	}
+	if !selectorObj.Empty() {
 		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selectorObj})
 	}
 
-	if err := k8sClient.List(ctx, buildRunList, listOpts...); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if args.Limit < 0 {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Limit must not be negative"}},
+		}, nil, nil
+	}
+	if args.Limit > 0 {
+		listOpts = append(listOpts, client.Limit(args.Limit))
+	}
+	if args.Continue != "" {
+		listOpts = append(listOpts, client.Continue(args.Continue))
+	}
+
+	status := args.Status
+	if status != "" {
+		valid := false
+		for _, s := range buildRunStatuses {
+			if strings.EqualFold(status, s) {
+				status = s
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Status must be one of: %s", strings.Join(buildRunStatuses, ", "))}},
+			}, nil, nil
+		}
+	}
+
+	var createdAfter, createdBefore time.Time
+	if args.CreatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, args.CreatedAfter)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid created-after time, expected RFC3339: %v", err)}},
+			}, nil, nil
+		}
+		createdAfter = t
+	}
+	if args.CreatedBefore != "" {
+		t, err := time.Parse(time.RFC3339, args.CreatedBefore)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid created-before time, expected RFC3339: %v", err)}},
+			}, nil, nil
+		}
+		createdBefore = t
+	}
+
+	switch args.SortBy {
+	case "", "creation", "completion":
+	default:
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Sort-by must be 'creation' or 'completion'"}},
+		}, nil, nil
+	}
+	switch args.SortOrder {
+	case "", "asc", "desc":
+	default:
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Sort-order must be 'asc' or 'desc'"}},
+		}, nil, nil
+	}
+
+	buildRunLists, skipped, err := listInScope(ctx, scope, func() *buildv1beta1.BuildRunList { return &buildv1beta1.BuildRunList{} }, listOpts)
+	if err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list buildruns: %v", err)}},
-		}, nil
+		}, nil, nil
+	}
+
+	var continueToken string
+	if !scope.multiple() {
+		continueToken = buildRunLists[0].Continue
 	}
 
 	var buildRuns []buildv1beta1.BuildRun
-	for _, buildRun := range buildRunList.Items {
-		if params.Arguments.Prefix == "" || strings.HasPrefix(buildRun.Name, params.Arguments.Prefix) {
-			buildRuns = append(buildRuns, buildRun)
+	for _, buildRun := range flattenBuildRunLists(buildRunLists) {
+		if args.Prefix != "" && !strings.HasPrefix(buildRun.Name, args.Prefix) {
+			continue
+		}
+		if status != "" && buildRunStatus(&buildRun) != status {
+			continue
 		}
+		if args.FailureReason != "" && !strings.EqualFold(buildRunFailureReason(&buildRun), args.FailureReason) {
+			continue
+		}
+		if !createdAfter.IsZero() && !buildRun.CreationTimestamp.Time.After(createdAfter) {
+			continue
+		}
+		if !createdBefore.IsZero() && !buildRun.CreationTimestamp.Time.Before(createdBefore) {
+			continue
+		}
+		buildRuns = append(buildRuns, buildRun)
+	}
+
+	if args.SortBy != "" {
+		sortBuildRuns(buildRuns, args.SortBy, args.SortOrder != "asc")
+	}
+	if scope.multiple() {
+		sortByNamespace(buildRuns, func(br *buildv1beta1.BuildRun) string { return br.Namespace })
 	}
 
 	if len(buildRuns) == 0 {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "No buildruns found"}},
-		}, nil
+		var result strings.Builder
+		if continueToken != "" {
+			result.WriteString(fmt.Sprintf("No buildruns found in this page\nContinue: %s\n", continueToken))
+		} else {
+			result.WriteString("No buildruns found")
+		}
+		writeSkippedNamespaces(&result, skipped)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+		}, nil, nil
 	}
 
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("Found %d buildrun(s):\n\n", len(buildRuns)))
 
+	var currentNamespace string
 	for _, buildRun := range buildRuns {
+		if scope.multiple() {
+			writeNamespaceHeader(&result, buildRun.Namespace, &currentNamespace)
+		}
 		result.WriteString(fmt.Sprintf("Name: %s\n", buildRun.Name))
 		result.WriteString(fmt.Sprintf("Namespace: %s\n", buildRun.Namespace))
 
@@ -95,32 +292,34 @@ func ListBuildRuns(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallT
 		result.WriteString("---\n")
 	}
 
-	return &mcp.CallToolResultFor[any]{
+	writeSkippedNamespaces(&result, skipped)
+	if continueToken != "" {
+		result.WriteString(fmt.Sprintf("\nMore results available, pass this token as 'continue' to fetch the next page:\nContinue: %s\n", continueToken))
+	}
+
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
 
-func GetBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.GetBuildRunParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func GetBuildRun(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildRunParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
 	buildRun := &buildv1beta1.BuildRun{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      params.Arguments.Name,
+	if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
 		Namespace: namespace,
 	}, buildRun); err != nil {
 		if errors.IsNotFound(err) {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", params.Arguments.Name, namespace)}},
-			}, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
 		}
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	var result strings.Builder
@@ -178,22 +377,23 @@ func GetBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToo
 
 	result.WriteString(fmt.Sprintf("Created: %s\n", buildRun.CreationTimestamp.Format("2006-01-02 15:04:05")))
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
 
-func CreateBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.CreateBuildRunParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func CreateBuildRun(ctx context.Context, req *mcp.CallToolRequest, args models.CreateBuildRunParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
-	if params.Arguments.BuildName == "" && params.Arguments.Strategy == "" {
-		return &mcp.CallToolResultFor[any]{
+	strategy := args.Strategy
+	if strategy == "" {
+		strategy = defaultStrategy
+	}
+	if args.BuildName == "" && strategy == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Either build-name or inline build spec (strategy, source-url, output-image) must be provided"}},
-		}, nil
+		}, nil, nil
 	}
 
 	buildRun := &buildv1beta1.BuildRun{
@@ -203,29 +403,29 @@ func CreateBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.Call
 		Spec: buildv1beta1.BuildRunSpec{},
 	}
 
-	if params.Arguments.Name != "" {
-		buildRun.Name = params.Arguments.Name
+	if args.Name != "" {
+		buildRun.Name = args.Name
 	} else {
 		buildRun.GenerateName = "buildrun-"
 	}
 
-	if params.Arguments.ServiceAccount != "" {
-		buildRun.Spec.ServiceAccount = &params.Arguments.ServiceAccount
+	if args.ServiceAccount != "" {
+		buildRun.Spec.ServiceAccount = &args.ServiceAccount
 	}
 
-	if params.Arguments.Timeout != "" {
-		duration, err := time.ParseDuration(params.Arguments.Timeout)
+	if args.Timeout != "" {
+		duration, err := time.ParseDuration(args.Timeout)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid timeout duration: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		buildRun.Spec.Timeout = &metav1.Duration{Duration: duration}
 	}
 
-	if len(params.Arguments.Parameters) > 0 {
-		for name, value := range params.Arguments.Parameters {
+	if len(args.Parameters) > 0 {
+		for name, value := range args.Parameters {
 			param := buildv1beta1.ParamValue{
 				Name: name,
 				SingleValue: &buildv1beta1.SingleValue{
@@ -236,35 +436,35 @@ func CreateBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.Call
 		}
 	}
 
-	if params.Arguments.BuildName != "" {
+	if args.BuildName != "" {
 		buildRun.Spec.Build = buildv1beta1.ReferencedBuild{
-			Name: &params.Arguments.BuildName,
+			Name: &args.BuildName,
 		}
 	} else {
-		if params.Arguments.SourceURL == "" || params.Arguments.OutputImage == "" {
-			return &mcp.CallToolResultFor[any]{
+		if args.SourceURL == "" || args.OutputImage == "" {
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: "source-url and output-image are required for inline build spec"}},
-			}, nil
+			}, nil, nil
 		}
 
 		buildSpec := &buildv1beta1.BuildSpec{
 			Strategy: buildv1beta1.Strategy{
-				Name: params.Arguments.Strategy,
+				Name: strategy,
 			},
 			Output: buildv1beta1.Image{
-				Image: params.Arguments.OutputImage,
+				Image: args.OutputImage,
 			},
 		}
 
-		strategyKind := params.Arguments.StrategyKind
+		strategyKind := args.StrategyKind
 		if strategyKind == "" {
-			strategyKind = "ClusterBuildStrategy"
+			strategyKind = defaultStrategyKind
 		}
 		kind := buildv1beta1.BuildStrategyKind(strategyKind)
 		buildSpec.Strategy.Kind = &kind
 
-		sourceType := buildv1beta1.BuildSourceType(params.Arguments.SourceType)
+		sourceType := buildv1beta1.BuildSourceType(args.SourceType)
 		if sourceType == "" {
 			sourceType = buildv1beta1.GitType
 		}
@@ -273,27 +473,27 @@ func CreateBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.Call
 			Type: sourceType,
 		}
 
-		if params.Arguments.ContextDir != "" {
-			buildSpec.Source.ContextDir = &params.Arguments.ContextDir
+		if args.ContextDir != "" {
+			buildSpec.Source.ContextDir = &args.ContextDir
 		}
 
 		switch sourceType {
 		case buildv1beta1.GitType:
 			buildSpec.Source.Git = &buildv1beta1.Git{
-				URL: params.Arguments.SourceURL,
+				URL: args.SourceURL,
 			}
-			if params.Arguments.Revision != "" {
-				buildSpec.Source.Git.Revision = &params.Arguments.Revision
+			if args.Revision != "" {
+				buildSpec.Source.Git.Revision = &args.Revision
 			}
 		case buildv1beta1.OCIArtifactType:
 			buildSpec.Source.OCIArtifact = &buildv1beta1.OCIArtifact{
-				Image: params.Arguments.SourceURL,
+				Image: args.SourceURL,
 			}
 		default:
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: "Source type must be 'Git' or 'OCI'"}},
-			}, nil
+			}, nil, nil
 		}
 
 		buildRun.Spec.Build = buildv1beta1.ReferencedBuild{
@@ -301,39 +501,37 @@ func CreateBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.Call
 		}
 	}
 
-	if err := k8sClient.Create(ctx, buildRun); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if err := kubeClient(ctx).Create(ctx, buildRun); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
+	toolLogger(ctx).Info("Created BuildRun", "namespace", namespace, "name", buildRun.Name)
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully created BuildRun '%s' in namespace '%s'", buildRun.Name, namespace)}},
-	}, nil
+	}, nil, nil
 }
 
-func RestartBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.RestartBuildRunParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func RestartBuildRun(ctx context.Context, req *mcp.CallToolRequest, args models.RestartBuildRunParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
 	originalBuildRun := &buildv1beta1.BuildRun{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      params.Arguments.Name,
+	if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
 		Namespace: namespace,
 	}, originalBuildRun); err != nil {
 		if errors.IsNotFound(err) {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", params.Arguments.Name, namespace)}},
-			}, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
 		}
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	newBuildRun := &buildv1beta1.BuildRun{
@@ -347,59 +545,67 @@ func RestartBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.Cal
 	}
 
 	if newBuildRun.Annotations != nil {
-		delete(newBuildRun.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		delete(newBuildRun.Annotations, lastAppliedConfigAnnotation)
 	}
 
-	if err := k8sClient.Create(ctx, newBuildRun); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if err := kubeClient(ctx).Create(ctx, newBuildRun); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create new buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
+	toolLogger(ctx).Info("Restarted BuildRun", "namespace", namespace, "name", args.Name, "newName", newBuildRun.Name)
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully restarted BuildRun '%s' as '%s' in namespace '%s'", params.Arguments.Name, newBuildRun.Name, namespace)}},
-	}, nil
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully restarted BuildRun '%s' as '%s' in namespace '%s'", args.Name, newBuildRun.Name, namespace)}},
+	}, nil, nil
 }
 
-func DeleteBuildRun(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.DeleteBuildRunParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func DeleteBuildRun(ctx context.Context, req *mcp.CallToolRequest, args models.DeleteBuildRunParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
-	if params.Arguments.Name == "" {
-		return &mcp.CallToolResultFor[any]{
+	if args.Name == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "BuildRun name is required"}},
-		}, nil
+		}, nil, nil
 	}
 
 	buildRun := &buildv1beta1.BuildRun{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      params.Arguments.Name,
+	if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
 		Namespace: namespace,
 	}, buildRun); err != nil {
 		if errors.IsNotFound(err) {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", params.Arguments.Name, namespace)}},
-			}, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
 		}
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
+	}
+
+	summary := fmt.Sprintf("Deleting BuildRun '%s' in namespace '%s'", buildRun.Name, namespace)
+	if buildName := buildRun.Spec.BuildName(); buildName != "" {
+		summary += fmt.Sprintf(" (Build '%s')", buildName)
+	}
+	summary += fmt.Sprintf(", status %s", buildRunStatus(buildRun))
+	if result := confirmDeletion(ctx, req, "delete_buildrun", args.Confirm, summary); result != nil {
+		return result, nil, nil
 	}
 
-	if err := k8sClient.Delete(ctx, buildRun); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if err := kubeClient(ctx).Delete(ctx, buildRun); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to delete buildrun: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
+	toolLogger(ctx).Info("Deleted BuildRun", "namespace", namespace, "name", args.Name)
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully deleted BuildRun '%s' from namespace '%s'", params.Arguments.Name, namespace)}},
-	}, nil
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully deleted BuildRun '%s' from namespace '%s'", args.Name, namespace)}},
+	}, nil, nil
 }
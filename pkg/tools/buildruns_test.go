@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildRunStatus(t *testing.T) {
+	succeeded := func(status corev1.ConditionStatus, reason string) buildv1beta1.Conditions {
+		return buildv1beta1.Conditions{{Type: buildv1beta1.Succeeded, Status: status, Reason: reason}}
+	}
+	now := metav1.Now()
+
+	tests := []struct {
+		name          string
+		status        buildv1beta1.BuildRunStatus
+		want          string
+		failureReason string
+	}{
+		{"no condition", buildv1beta1.BuildRunStatus{}, "Pending", ""},
+		{"succeeded", buildv1beta1.BuildRunStatus{Conditions: succeeded(corev1.ConditionTrue, "Succeeded")}, "Succeeded", ""},
+		{"failed", buildv1beta1.BuildRunStatus{Conditions: succeeded(corev1.ConditionFalse, "BuildRunTimeout")}, "Failed", "BuildRunTimeout"},
+		{"failure details win", buildv1beta1.BuildRunStatus{
+			Conditions:     succeeded(corev1.ConditionFalse, "Failed"),
+			FailureDetails: &buildv1beta1.FailureDetails{Reason: "StepOutOfMemory"},
+		}, "Failed", "StepOutOfMemory"},
+		{"canceled", buildv1beta1.BuildRunStatus{Conditions: succeeded(corev1.ConditionFalse, buildv1beta1.BuildRunStateCancel)}, "Canceled", "BuildRunCanceled"},
+		{"running", buildv1beta1.BuildRunStatus{Conditions: succeeded(corev1.ConditionUnknown, "Running"), StartTime: &now}, "Running", ""},
+		{"not started", buildv1beta1.BuildRunStatus{Conditions: succeeded(corev1.ConditionUnknown, "Pending")}, "Pending", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buildRun := &buildv1beta1.BuildRun{Status: test.status}
+			if got := buildRunStatus(buildRun); got != test.want {
+				t.Errorf("buildRunStatus() = %s, want %s", got, test.want)
+			}
+			if got := buildRunFailureReason(buildRun); got != test.failureReason {
+				t.Errorf("buildRunFailureReason() = %q, want %q", got, test.failureReason)
+			}
+		})
+	}
+}
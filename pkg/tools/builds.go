@@ -15,61 +15,91 @@ import (
 	"github.com/shipwright-io/build/server/pkg/models"
 )
 
-var k8sClient client.Client
+var defaultNamespace string
 
-func SetClient(c client.Client) {
-	k8sClient = c
-}
+var defaultStrategy string
+
+var defaultStrategyKind = string(buildv1beta1.ClusterBuildStrategyKind)
 
-func ListBuilds(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.ListBuildsParams]) (*mcp.CallToolResultFor[any], error) {
-	buildList := &buildv1beta1.BuildList{}
+func SetDefaults(namespace, strategy, strategyKind string) {
+	defaultNamespace = namespace
+	defaultStrategy = strategy
+	defaultStrategyKind = strategyKind
+}
 
-	listOpts := []client.ListOption{
-		client.InNamespace(params.Arguments.Namespace),
+func ListBuilds(ctx context.Context, req *mcp.CallToolRequest, args models.ListBuildsParams) (*mcp.CallToolResult, any, error) {
+	scope := namespaceScope{
+		namespace:     args.Namespace,
+		namespaces:    args.Namespaces,
+		allNamespaces: args.AllNamespaces,
+	}
+	if err := scope.validate(); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid namespace scope: %v", err)}},
+		}, nil, nil
 	}
+	if !scope.multiple() {
+		scope.namespace = resolveNamespace(ctx, req, scope.namespace)
+	}
+
+	var listOpts []client.ListOption
 
-	if params.Arguments.LabelSelector != "" {
-		selector, err := metav1.ParseToLabelSelector(params.Arguments.LabelSelector)
+	if args.LabelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(args.LabelSelector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		selectorObj, err := metav1.LabelSelectorAsSelector(selector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selectorObj})
 	}
 
-	if err := k8sClient.List(ctx, buildList, listOpts...); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	buildLists, skipped, err := listInScope(ctx, scope, func() *buildv1beta1.BuildList { return &buildv1beta1.BuildList{} }, listOpts)
+	if err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list builds: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	var builds []buildv1beta1.Build
-	for _, build := range buildList.Items {
-		if params.Arguments.Prefix == "" || strings.HasPrefix(build.Name, params.Arguments.Prefix) {
-			builds = append(builds, build)
+	for _, buildList := range buildLists {
+		for _, build := range buildList.Items {
+			if args.Prefix == "" || strings.HasPrefix(build.Name, args.Prefix) {
+				builds = append(builds, build)
+			}
 		}
 	}
 
 	if len(builds) == 0 {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "No builds found"}},
-		}, nil
+		var result strings.Builder
+		result.WriteString("No builds found")
+		writeSkippedNamespaces(&result, skipped)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+		}, nil, nil
 	}
 
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("Found %d build(s):\n\n", len(builds)))
 
+	var currentNamespace string
+	if scope.multiple() {
+		sortByNamespace(builds, func(b *buildv1beta1.Build) string { return b.Namespace })
+	}
 	for _, build := range builds {
+		if scope.multiple() {
+			writeNamespaceHeader(&result, build.Namespace, &currentNamespace)
+		}
 		result.WriteString(fmt.Sprintf("Name: %s\n", build.Name))
 		result.WriteString(fmt.Sprintf("Namespace: %s\n", build.Namespace))
 		result.WriteString(fmt.Sprintf("Strategy: %s (%s)\n", build.Spec.Strategy.Name, *build.Spec.Strategy.Kind))
@@ -83,33 +113,31 @@ func ListBuilds(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallTool
 		result.WriteString(fmt.Sprintf("Created: %s\n", build.CreationTimestamp.Format("2006-01-02 15:04:05")))
 		result.WriteString("---\n")
 	}
+	writeSkippedNamespaces(&result, skipped)
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
 
-func GetBuild(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.GetBuildParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func GetBuild(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
 	build := &buildv1beta1.Build{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      params.Arguments.Name,
+	if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
 		Namespace: namespace,
 	}, build); err != nil {
 		if errors.IsNotFound(err) {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", params.Arguments.Name, namespace)}},
-			}, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
 		}
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	var result strings.Builder
@@ -140,94 +168,95 @@ func GetBuild(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolPa
 	}
 	result.WriteString(fmt.Sprintf("Created: %s\n", build.CreationTimestamp.Format("2006-01-02 15:04:05")))
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
 
-func CreateBuild(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.CreateBuildParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func CreateBuild(ctx context.Context, req *mcp.CallToolRequest, args models.CreateBuildParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
-	if params.Arguments.Name == "" {
-		return &mcp.CallToolResultFor[any]{
+	if args.Name == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Build name is required"}},
-		}, nil
+		}, nil, nil
 	}
-	if params.Arguments.SourceURL == "" {
-		return &mcp.CallToolResultFor[any]{
+	if args.SourceURL == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Source URL is required"}},
-		}, nil
+		}, nil, nil
+	}
+	strategy := args.Strategy
+	if strategy == "" {
+		strategy = defaultStrategy
 	}
-	if params.Arguments.Strategy == "" {
-		return &mcp.CallToolResultFor[any]{
+	if strategy == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Strategy name is required"}},
-		}, nil
+		}, nil, nil
 	}
-	if params.Arguments.OutputImage == "" {
-		return &mcp.CallToolResultFor[any]{
+	if args.OutputImage == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Output image is required"}},
-		}, nil
+		}, nil, nil
 	}
 
 	build := &buildv1beta1.Build{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      params.Arguments.Name,
+			Name:      args.Name,
 			Namespace: namespace,
 		},
 		Spec: buildv1beta1.BuildSpec{
 			Strategy: buildv1beta1.Strategy{
-				Name: params.Arguments.Strategy,
+				Name: strategy,
 			},
 			Output: buildv1beta1.Image{
-				Image: params.Arguments.OutputImage,
+				Image: args.OutputImage,
 			},
 		},
 	}
 
-	strategyKind := params.Arguments.StrategyKind
+	strategyKind := args.StrategyKind
 	if strategyKind == "" {
-		strategyKind = "ClusterBuildStrategy"
+		strategyKind = defaultStrategyKind
 	}
 	kind := buildv1beta1.BuildStrategyKind(strategyKind)
 	build.Spec.Strategy.Kind = &kind
 
-	sourceType := buildv1beta1.BuildSourceType(params.Arguments.SourceType)
+	sourceType := buildv1beta1.BuildSourceType(args.SourceType)
 	build.Spec.Source = &buildv1beta1.Source{
 		Type: sourceType,
 	}
 
-	if params.Arguments.ContextDir != "" {
-		build.Spec.Source.ContextDir = &params.Arguments.ContextDir
+	if args.ContextDir != "" {
+		build.Spec.Source.ContextDir = &args.ContextDir
 	}
 
 	switch sourceType {
 	case buildv1beta1.GitType:
 		build.Spec.Source.Git = &buildv1beta1.Git{
-			URL: params.Arguments.SourceURL,
+			URL: args.SourceURL,
 		}
-		if params.Arguments.Revision != "" {
-			build.Spec.Source.Git.Revision = &params.Arguments.Revision
+		if args.Revision != "" {
+			build.Spec.Source.Git.Revision = &args.Revision
 		}
 	case buildv1beta1.OCIArtifactType:
 		build.Spec.Source.OCIArtifact = &buildv1beta1.OCIArtifact{
-			Image: params.Arguments.SourceURL,
+			Image: args.SourceURL,
 		}
 	default:
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Source type must be 'Git' or 'OCI'"}},
-		}, nil
+		}, nil, nil
 	}
 
-	if len(params.Arguments.Parameters) > 0 {
-		for name, value := range params.Arguments.Parameters {
+	if len(args.Parameters) > 0 {
+		for name, value := range args.Parameters {
 			param := buildv1beta1.ParamValue{
 				Name: name,
 				SingleValue: &buildv1beta1.SingleValue{
@@ -238,67 +267,136 @@ func CreateBuild(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToo
 		}
 	}
 
-	if params.Arguments.Timeout != "" {
-		duration, err := time.ParseDuration(params.Arguments.Timeout)
+	if args.Timeout != "" {
+		duration, err := time.ParseDuration(args.Timeout)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid timeout duration: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		build.Spec.Timeout = &metav1.Duration{Duration: duration}
 	}
 
-	if err := k8sClient.Create(ctx, build); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if err := kubeClient(ctx).Create(ctx, build); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create build: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
+	toolLogger(ctx).Info("Created Build", "namespace", namespace, "name", args.Name)
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully created Build '%s' in namespace '%s'", params.Arguments.Name, namespace)}},
-	}, nil
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully created Build '%s' in namespace '%s'", args.Name, namespace)}},
+	}, nil, nil
 }
 
-func DeleteBuild(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.DeleteBuildParams]) (*mcp.CallToolResultFor[any], error) {
-	namespace := params.Arguments.Namespace
-	if namespace == "" {
-		namespace = "default"
-	}
+func DeleteBuild(ctx context.Context, req *mcp.CallToolRequest, args models.DeleteBuildParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
 
-	if params.Arguments.Name == "" {
-		return &mcp.CallToolResultFor[any]{
+	if args.Name == "" {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: "Build name is required"}},
-		}, nil
+		}, nil, nil
 	}
 
 	build := &buildv1beta1.Build{}
-	if err := k8sClient.Get(ctx, client.ObjectKey{
-		Name:      params.Arguments.Name,
+	if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
 		Namespace: namespace,
 	}, build); err != nil {
 		if errors.IsNotFound(err) {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
-				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", params.Arguments.Name, namespace)}},
-			}, nil
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
 		}
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
-	if err := k8sClient.Delete(ctx, build); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := apiReader(ctx).List(ctx, buildRunList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{buildv1beta1.LabelBuild: build.Name},
+	); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list buildruns: %v", err)}},
+		}, nil, nil
+	}
+
+	var deleteOpts []client.DeleteOption
+	var buildRuns []buildv1beta1.BuildRun
+	switch args.Cascade {
+	case "":
+		for _, buildRun := range buildRunList.Items {
+			if metav1.IsControlledBy(&buildRun, build) {
+				buildRuns = append(buildRuns, buildRun)
+			}
+		}
+	case "none":
+		deleteOpts = append(deleteOpts, client.PropagationPolicy(metav1.DeletePropagationOrphan))
+	case "background":
+		deleteOpts = append(deleteOpts, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		buildRuns = buildRunList.Items
+	case "foreground":
+		deleteOpts = append(deleteOpts, client.PropagationPolicy(metav1.DeletePropagationForeground))
+		buildRuns = buildRunList.Items
+	default:
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Cascade must be 'none', 'background' or 'foreground'"}},
+		}, nil, nil
+	}
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("Deleting Build '%s' in namespace '%s'", build.Name, namespace))
+	switch {
+	case len(buildRuns) > 0:
+		summary.WriteString(fmt.Sprintf(" will also delete %d BuildRun(s):\n", len(buildRuns)))
+		for _, buildRun := range buildRuns {
+			summary.WriteString(fmt.Sprintf("  - %s\n", buildRun.Name))
+		}
+	case len(buildRunList.Items) > 0:
+		summary.WriteString(fmt.Sprintf(" will keep its %d BuildRun(s)\n", len(buildRunList.Items)))
+	default:
+		summary.WriteString(" will not delete any BuildRuns\n")
+	}
+
+	if result := confirmDeletion(ctx, req, "delete_build", args.Confirm, strings.TrimSuffix(summary.String(), "\n")); result != nil {
+		return result, nil, nil
+	}
+
+	if err := kubeClient(ctx).Delete(ctx, build, deleteOpts...); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to delete build: %v", err)}},
-		}, nil
+		}, nil, nil
+	}
+	toolLogger(ctx).Info("Deleted Build", "namespace", namespace, "name", args.Name, "cascade", args.Cascade)
+
+	if args.Cascade == "background" || args.Cascade == "foreground" {
+		for i := range buildRuns {
+			if err := kubeClient(ctx).Delete(ctx, &buildRuns[i], deleteOpts...); err != nil && !errors.IsNotFound(err) {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Deleted Build '%s' but failed to delete buildrun '%s': %v", build.Name, buildRuns[i].Name, err)}},
+				}, nil, nil
+			}
+			toolLogger(ctx).Info("Deleted BuildRun of deleted Build", "namespace", namespace, "name", buildRuns[i].Name, "build", build.Name)
+		}
 	}
 
-	return &mcp.CallToolResultFor[any]{
-		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully deleted Build '%s' from namespace '%s'", params.Arguments.Name, namespace)}},
-	}, nil
+	if len(buildRuns) > 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully deleted Build '%s' and %d BuildRun(s) from namespace '%s'", args.Name, len(buildRuns), namespace)}},
+		}, nil, nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Successfully deleted Build '%s' from namespace '%s'", args.Name, namespace)}},
+	}, nil, nil
 }
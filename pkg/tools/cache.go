@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const cacheRetryInterval = 30 * time.Second
+
+// cachedObjects are the types whose reads are served from the informer cache
+// of a cluster when it has one.
+var cachedObjects = []client.Object{
+	&buildv1beta1.Build{},
+	&buildv1beta1.BuildRun{},
+	&buildv1beta1.BuildStrategy{},
+	&buildv1beta1.ClusterBuildStrategy{},
+}
+
+// cacheStatus tracks how current the informer cache of a cluster is.
+type cacheStatus struct {
+	mu         sync.Mutex
+	syncedAt   time.Time
+	lastChange time.Time
+}
+
+func (s *cacheStatus) synced() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.syncedAt.IsZero()
+}
+
+func (s *cacheStatus) observeChange() {
+	s.mu.Lock()
+	s.lastChange = time.Now()
+	s.mu.Unlock()
+}
+
+func (s *cacheStatus) describe() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	description := fmt.Sprintf("Data Source: informer cache (synced %s ago", time.Since(s.syncedAt).Round(time.Second))
+	if s.lastChange.After(s.syncedAt) {
+		description += fmt.Sprintf(", last change seen %s ago", time.Since(s.lastChange).Round(time.Second))
+	}
+	return description + "), recent changes may take a moment to appear"
+}
+
+type cacheReadsKey struct{}
+
+// cacheReads records whether a tool call read any object from a cache.
+type cacheReads struct {
+	mu     sync.Mutex
+	status *cacheStatus
+}
+
+func markCacheRead(ctx context.Context, status *cacheStatus) {
+	if reads, ok := ctx.Value(cacheReadsKey{}).(*cacheReads); ok {
+		reads.mu.Lock()
+		reads.status = status
+		reads.mu.Unlock()
+	}
+}
+
+// cachedClient serves reads of Shipwright objects from the informer cache once
+// it has synced. Writes, paginated lists and reads of other types go to the
+// API server, as do all reads while the cache is still syncing.
+type cachedClient struct {
+	client.Client
+	cache  cache.Cache
+	status *cacheStatus
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if !c.status.synced() || !isCached(obj) {
+		return c.Client.Get(ctx, key, obj, opts...)
+	}
+	markCacheRead(ctx, c.status)
+	return c.cache.Get(ctx, key, obj, opts...)
+}
+
+func (c *cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	if !c.status.synced() || !isCached(list) || listOptions.Limit > 0 || listOptions.Continue != "" {
+		return c.Client.List(ctx, list, opts...)
+	}
+	markCacheRead(ctx, c.status)
+	return c.cache.List(ctx, list, opts...)
+}
+
+func isCached(obj any) bool {
+	switch obj.(type) {
+	case *buildv1beta1.Build, *buildv1beta1.BuildList,
+		*buildv1beta1.BuildRun, *buildv1beta1.BuildRunList,
+		*buildv1beta1.BuildStrategy, *buildv1beta1.BuildStrategyList,
+		*buildv1beta1.ClusterBuildStrategy, *buildv1beta1.ClusterBuildStrategyList:
+		return true
+	}
+	return false
+}
+
+// StartCaches starts the informers of every cluster that has a cache in the
+// background, and switches the cluster's reads to the cache once they have
+// synced. Until then, and while its API server cannot be reached to set up the
+// informers, a cluster's reads go to the API server.
+func StartCaches(ctx context.Context) {
+	for _, name := range clusterNames() {
+		if cluster := clusters[name]; cluster.Cache != nil {
+			go runCache(ctx, cluster)
+		}
+	}
+}
+
+func runCache(ctx context.Context, cluster *Cluster) {
+	go func() {
+		if err := cluster.Cache.Start(ctx); err != nil {
+			slog.Error("Informer cache stopped", "context", cluster.Name, "error", err)
+		}
+	}()
+
+	for {
+		err := addCacheInformers(ctx, cluster)
+		if err == nil {
+			break
+		}
+		slog.Warn("Failed to set up informer cache, reads go to the API server", "context", cluster.Name, "error", err, "retryIn", cacheRetryInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cacheRetryInterval):
+		}
+	}
+
+	if !cluster.Cache.WaitForCacheSync(ctx) {
+		return
+	}
+	cluster.cacheStatus.mu.Lock()
+	cluster.cacheStatus.syncedAt = time.Now()
+	cluster.cacheStatus.mu.Unlock()
+	slog.Info("Informer cache synced, reads are served from the cache", "context", cluster.Name)
+}
+
+func addCacheInformers(ctx context.Context, cluster *Cluster) error {
+	for _, obj := range cachedObjects {
+		informer, err := cluster.Cache.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			return fmt.Errorf("failed to create informer for %T: %w", obj, err)
+		}
+		if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { cluster.cacheStatus.observeChange() },
+			UpdateFunc: func(any, any) { cluster.cacheStatus.observeChange() },
+			DeleteFunc: func(any) { cluster.cacheStatus.observeChange() },
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withCacheStatus adds how current the cache is to successful results of
+// calls that read from it. The status is a separate content item, so the
+// tool's own output, such as an exported manifest, is left as it is.
+func withCacheStatus[In any](handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		reads := &cacheReads{}
+		result, out, err := handler(context.WithValue(ctx, cacheReadsKey{}, reads), req, args)
+		if err != nil || result == nil || result.IsError || reads.status == nil {
+			return result, out, err
+		}
+		result.Content = append(result.Content, &mcp.TextContent{Text: reads.status.describe()})
+		return result, out, err
+	}
+}
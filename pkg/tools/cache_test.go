@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// readerCache is a synced informer cache that serves reads from a client.
+type readerCache struct {
+	cache.Cache
+	reader client.Reader
+}
+
+func (c readerCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return c.reader.Get(ctx, key, obj, opts...)
+}
+
+func (c readerCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.reader.List(ctx, list, opts...)
+}
+
+func testBuild(strategy string) *buildv1beta1.Build {
+	return &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a", ResourceVersion: "1"},
+		Spec: buildv1beta1.BuildSpec{
+			Strategy: buildv1beta1.Strategy{Name: strategy},
+			Output:   buildv1beta1.Image{Image: "registry.example.com/app"},
+		},
+	}
+}
+
+// addCachedCluster registers a cluster whose API server holds current and
+// whose synced cache holds cached.
+func addCachedCluster(t *testing.T, current, cached []client.Object) context.Context {
+	t.Helper()
+	cluster := &Cluster{
+		Client: newFakeClient(t, current...),
+		Cache:  readerCache{reader: newFakeClient(t, cached...)},
+	}
+	ctx := addTestCluster(t, cluster)
+	cluster.cacheStatus.syncedAt = time.Now()
+	return ctx
+}
+
+func TestWithCacheStatus(t *testing.T) {
+	ctx := addCachedCluster(t, nil, []client.Object{testBuild("buildah")})
+
+	handler := withCacheStatus(func(ctx context.Context, req *mcp.CallToolRequest, args models.ListBuildsParams) (*mcp.CallToolResult, any, error) {
+		var builds buildv1beta1.BuildList
+		if err := kubeClient(ctx).List(ctx, &builds); err != nil {
+			return nil, nil, err
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Found 1 build(s):\n"}}}, nil, nil
+	})
+	result, _, err := handler(ctx, nil, models.ListBuildsParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("got %d content items, want the output and the cache status", len(result.Content))
+	}
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "Found 1 build(s):\n" {
+		t.Errorf("output = %q, want it unchanged", text)
+	}
+	if text := result.Content[1].(*mcp.TextContent).Text; !strings.HasPrefix(text, "Data Source: informer cache") {
+		t.Errorf("cache status = %q", text)
+	}
+}
+
+func TestExportResourceWithCache(t *testing.T) {
+	ctx := addCachedCluster(t, []client.Object{testBuild("buildkit")}, []client.Object{testBuild("buildah")})
+
+	result, _, err := withCacheStatus(ExportResource)(ctx, nil, models.ExportResourceParams{Kind: "Build", Name: "app", Namespace: "team-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsError || len(result.Content) != 1 {
+		t.Fatalf("result = %+v, want a single manifest", result)
+	}
+	var build buildv1beta1.Build
+	if err := yaml.UnmarshalStrict([]byte(result.Content[0].(*mcp.TextContent).Text), &build); err != nil {
+		t.Fatalf("exported manifest is not a valid Build: %v", err)
+	}
+	if build.Spec.Strategy.Name != "buildkit" {
+		t.Errorf("strategy = %s, want the API server's buildkit rather than the cached buildah", build.Spec.Strategy.Name)
+	}
+}
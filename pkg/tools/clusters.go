@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const (
+	shipwrightControllerNamespace  = "shipwright-build"
+	shipwrightControllerDeployment = "shipwright-build-controller"
+	clusterCheckTimeout            = 5 * time.Second
+)
+
+type Cluster struct {
+	Name      string
+	Host      string
+	Namespace string
+	Client    client.Client
+	Clientset kubernetes.Interface
+	// Cache, when set, is the informer cache that reads of Shipwright objects
+	// are served from once StartCaches has synced it.
+	Cache cache.Cache
+
+	cacheStatus *cacheStatus
+	apiReader   client.Reader
+}
+
+type clusterKey struct{}
+
+var clusters = map[string]*Cluster{}
+
+var defaultCluster string
+
+// AddCluster registers a cluster that tools can select through their context
+// argument. The first cluster added, or the one added with isDefault, is used
+// when no context is given. The cluster's client is wrapped to trace its calls
+// and count the BuildRuns it creates, and to read from its cache if it has one.
+// Tools that modify the cluster read through apiReader instead, so that they
+// never act on a stale cached object.
+func AddCluster(cluster *Cluster, isDefault bool) {
+	cluster.apiReader = tracingClient{Client: cluster.Client, cluster: cluster.Name}
+	if cluster.Cache != nil {
+		cluster.cacheStatus = &cacheStatus{}
+		cluster.Client = &cachedClient{Client: cluster.Client, cache: cluster.Cache, status: cluster.cacheStatus}
+	}
+	cluster.Client = tracingClient{Client: countingClient{Client: cluster.Client}, cluster: cluster.Name}
+	clusters[cluster.Name] = cluster
+	if isDefault || defaultCluster == "" {
+		defaultCluster = cluster.Name
+	}
+}
+
+func lookupCluster(name string) (*Cluster, error) {
+	if name == "" {
+		name = defaultCluster
+	}
+	cluster, ok := clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown context '%s', available contexts: %s", name, strings.Join(clusterNames(), ", "))
+	}
+	return cluster, nil
+}
+
+func clusterNames() []string {
+	names := make([]string, 0, len(clusters))
+	for name := range clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func withCluster(ctx context.Context, cluster *Cluster) context.Context {
+	return context.WithValue(ctx, clusterKey{}, cluster)
+}
+
+func currentCluster(ctx context.Context) *Cluster {
+	if cluster, ok := ctx.Value(clusterKey{}).(*Cluster); ok {
+		return cluster
+	}
+	return clusters[defaultCluster]
+}
+
+func kubeClient(ctx context.Context) client.Client {
+	return currentCluster(ctx).Client
+}
+
+// apiReader reads from the API server of the current cluster, bypassing its
+// informer cache.
+func apiReader(ctx context.Context) client.Reader {
+	return currentCluster(ctx).apiReader
+}
+
+func kubeClientset(ctx context.Context) kubernetes.Interface {
+	return currentCluster(ctx).Clientset
+}
+
+func ListClusters(ctx context.Context, req *mcp.CallToolRequest, args models.ListClustersParams) (*mcp.CallToolResult, any, error) {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Found %d cluster(s):\n\n", len(clusters)))
+
+	for _, name := range clusterNames() {
+		cluster := clusters[name]
+		checkCtx, cancel := context.WithTimeout(ctx, clusterCheckTimeout)
+
+		result.WriteString(fmt.Sprintf("Context: %s", name))
+		if name == defaultCluster {
+			result.WriteString(" (default)")
+		}
+		result.WriteString("\n")
+		result.WriteString(fmt.Sprintf("  Server: %s\n", cluster.Host))
+
+		serverVersion, err := kubernetesVersion(checkCtx, cluster)
+		if err != nil {
+			result.WriteString(fmt.Sprintf("  Reachable: no (%v)\n\n", err))
+			cancel()
+			continue
+		}
+		result.WriteString("  Reachable: yes\n")
+		result.WriteString(fmt.Sprintf("  Kubernetes Version: %s\n", serverVersion))
+		result.WriteString(fmt.Sprintf("  Shipwright: %s\n\n", shipwrightVersion(checkCtx, cluster)))
+		cancel()
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+func kubernetesVersion(ctx context.Context, cluster *Cluster) (string, error) {
+	body, err := cluster.Clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return "", err
+	}
+	var info version.Info
+	if err := json.Unmarshal(body, &info); err != nil {
+		return "", err
+	}
+	return info.GitVersion, nil
+}
+
+// shipwrightVersion describes the installed Shipwright Build release, based on
+// the served API and the controller Deployment of the default installation.
+func shipwrightVersion(ctx context.Context, cluster *Cluster) string {
+	if err := cluster.Clientset.Discovery().RESTClient().Get().AbsPath("/apis", buildv1beta1.SchemeGroupVersion.String()).Do(ctx).Error(); err != nil {
+		if errors.IsNotFound(err) {
+			return "not installed"
+		}
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+
+	deployment, err := cluster.Clientset.AppsV1().Deployments(shipwrightControllerNamespace).Get(ctx, shipwrightControllerDeployment, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Sprintf("%s API served, controller version unknown (%v)", buildv1beta1.SchemeGroupVersion, err)
+	}
+
+	release := deployment.Labels["app.kubernetes.io/version"]
+	if release == "" && len(deployment.Spec.Template.Spec.Containers) > 0 {
+		image := deployment.Spec.Template.Spec.Containers[0].Image
+		if i := strings.LastIndex(image, ":"); i != -1 && !strings.Contains(image[i:], "/") && !strings.Contains(image, "@") {
+			release = image[i+1:]
+		}
+	}
+	if release == "" {
+		release = "unknown"
+	}
+	return fmt.Sprintf("%s (controller %d/%d ready)", release, deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+}
@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// addTestCluster registers cluster as the default cluster, with a fake client
+// holding objects unless it has a client already, and removes it when the
+// test ends.
+func addTestCluster(t *testing.T, cluster *Cluster, objects ...client.Object) context.Context {
+	t.Helper()
+	if cluster.Name == "" {
+		cluster.Name = "test"
+	}
+	if cluster.Client == nil {
+		cluster.Client = newFakeClient(t, objects...)
+	}
+	AddCluster(cluster, true)
+	t.Cleanup(func() {
+		delete(clusters, cluster.Name)
+		defaultCluster = ""
+	})
+	return withCluster(context.Background(), cluster)
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := buildv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
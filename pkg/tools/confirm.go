@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var confirmationSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"confirm": map[string]any{
+			"type":        "boolean",
+			"title":       "Confirm",
+			"description": "Proceed with the deletion",
+		},
+	},
+}
+
+// confirmDeletion asks the user to approve the deletion described by summary.
+// Clients that support elicitation are prompted directly, for all others the
+// tool must have been called with confirm set to true. A nil result means the
+// deletion may go ahead, otherwise the result should be returned as is.
+func confirmDeletion(ctx context.Context, req *mcp.CallToolRequest, tool string, confirm bool, summary string) *mcp.CallToolResult {
+	if !supportsElicitation(req) {
+		if confirm {
+			return nil
+		}
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s\nCall %s again with confirm set to true to proceed", summary, tool)}},
+		}
+	}
+
+	elicitResult, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         summary + "\nDo you want to proceed?",
+		RequestedSchema: confirmationSchema,
+	})
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to ask for confirmation: %v", err)}},
+		}
+	}
+
+	if elicitResult.Action != "accept" || elicitResult.Content["confirm"] != true {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Deletion was not confirmed, nothing was deleted"}},
+		}
+	}
+	return nil
+}
+
+func supportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	initializeParams := req.Session.InitializeParams()
+	return initializeParams != nil && initializeParams.Capabilities != nil && initializeParams.Capabilities.Elicitation != nil
+}
@@ -0,0 +1,212 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+func CopyBuild(ctx context.Context, req *mcp.CallToolRequest, args models.CopyBuildParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Name == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Build name is required"}},
+		}, nil, nil
+	}
+
+	targetName := args.TargetName
+	if targetName == "" {
+		targetName = args.Name
+	}
+	targetNamespace := args.TargetNamespace
+	if targetNamespace == "" {
+		targetNamespace = namespace
+	}
+	if targetName == args.Name && targetNamespace == namespace {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Either target-name or target-namespace must differ from the source Build"}},
+		}, nil, nil
+	}
+
+	source := &buildv1beta1.Build{}
+	if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
+		Namespace: namespace,
+	}, source); err != nil {
+		if errors.IsNotFound(err) {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
+		}
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
+		}, nil, nil
+	}
+
+	build := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        targetName,
+			Namespace:   targetNamespace,
+			Labels:      maps.Clone(source.Labels),
+			Annotations: maps.Clone(source.Annotations),
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	if build.Annotations != nil {
+		delete(build.Annotations, lastAppliedConfigAnnotation)
+	}
+
+	if args.Revision != "" {
+		if build.Spec.Source == nil || build.Spec.Source.Git == nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: "Revision can only be overridden for Builds with a Git source"}},
+			}, nil, nil
+		}
+		build.Spec.Source.Git.Revision = &args.Revision
+	}
+
+	if args.OutputImage != "" {
+		build.Spec.Output.Image = args.OutputImage
+	}
+
+	if len(args.Parameters) > 0 {
+		names := make([]string, 0, len(args.Parameters))
+		for name := range args.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := args.Parameters[name]
+			param := buildv1beta1.ParamValue{
+				Name: name,
+				SingleValue: &buildv1beta1.SingleValue{
+					Value: &value,
+				},
+			}
+			replaced := false
+			for i := range build.Spec.ParamValues {
+				if build.Spec.ParamValues[i].Name == name {
+					build.Spec.ParamValues[i] = param
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				build.Spec.ParamValues = append(build.Spec.ParamValues, param)
+			}
+		}
+	}
+
+	warnings := checkBuildReferences(ctx, build)
+
+	if err := kubeClient(ctx).Create(ctx, build); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create build: %v", err)}},
+		}, nil, nil
+	}
+	toolLogger(ctx).Info("Copied Build", "namespace", build.Namespace, "name", build.Name, "warnings", len(warnings))
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Successfully copied Build '%s' from namespace '%s' to Build '%s' in namespace '%s'\n", args.Name, namespace, targetName, targetNamespace))
+	if len(warnings) > 0 {
+		result.WriteString("Warnings:\n")
+		for _, warning := range warnings {
+			result.WriteString(fmt.Sprintf("  - %s\n", warning))
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+// checkBuildReferences returns a warning for every secret and strategy the
+// Build references that does not exist in, or cannot be read from, its
+// namespace.
+func checkBuildReferences(ctx context.Context, build *buildv1beta1.Build) []string {
+	var warnings []string
+
+	secrets := map[string]string{}
+	if build.Spec.Output.PushSecret != nil {
+		secrets[*build.Spec.Output.PushSecret] = "output push secret"
+	}
+	if secret := build.GetSourceCredentials(); secret != nil {
+		secrets[*secret] = "source secret"
+	}
+	for _, param := range build.Spec.ParamValues {
+		values := param.Values
+		if param.SingleValue != nil {
+			values = append(values, *param.SingleValue)
+		}
+		for _, value := range values {
+			if value.SecretValue != nil {
+				secrets[value.SecretValue.Name] = fmt.Sprintf("secret of parameter '%s'", param.Name)
+			}
+		}
+	}
+	for _, env := range build.Spec.Env {
+		if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+			secrets[env.ValueFrom.SecretKeyRef.Name] = fmt.Sprintf("secret of environment variable '%s'", env.Name)
+		}
+	}
+	for _, volume := range build.Spec.Volumes {
+		if volume.Secret != nil {
+			secrets[volume.Secret.SecretName] = fmt.Sprintf("secret of volume '%s'", volume.Name)
+		}
+	}
+
+	secretNames := make([]string, 0, len(secrets))
+	for secretName := range secrets {
+		secretNames = append(secretNames, secretName)
+	}
+	sort.Strings(secretNames)
+	for _, secretName := range secretNames {
+		_, err := kubeClientset(ctx).CoreV1().Secrets(build.Namespace).Get(ctx, secretName, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			warnings = append(warnings, fmt.Sprintf("%s '%s' does not exist in namespace '%s'", secrets[secretName], secretName, build.Namespace))
+		case err != nil:
+			warnings = append(warnings, fmt.Sprintf("could not check %s '%s': %v", secrets[secretName], secretName, err))
+		}
+	}
+
+	var strategy client.Object = &buildv1beta1.ClusterBuildStrategy{}
+	strategyKey := client.ObjectKey{Name: build.Spec.Strategy.Name}
+	strategyKind := "ClusterBuildStrategy"
+	if build.Spec.Strategy.Kind != nil && *build.Spec.Strategy.Kind == buildv1beta1.NamespacedBuildStrategyKind {
+		strategy = &buildv1beta1.BuildStrategy{}
+		strategyKey.Namespace = build.Namespace
+		strategyKind = "BuildStrategy"
+	}
+	if err := apiReader(ctx).Get(ctx, strategyKey, strategy); err != nil {
+		if errors.IsNotFound(err) {
+			if strategyKey.Namespace != "" {
+				warnings = append(warnings, fmt.Sprintf("%s '%s' does not exist in namespace '%s'", strategyKind, strategyKey.Name, strategyKey.Namespace))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s '%s' does not exist", strategyKind, strategyKey.Name))
+			}
+		} else {
+			warnings = append(warnings, fmt.Sprintf("could not check %s '%s': %v", strategyKind, strategyKey.Name, err))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+func TestCopyBuild(t *testing.T) {
+	gitBuild := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "team-a",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{lastAppliedConfigAnnotation: "{}", "owner": "alice"},
+		},
+		Spec: buildv1beta1.BuildSpec{
+			Source: &buildv1beta1.Source{
+				Type: buildv1beta1.GitType,
+				Git:  &buildv1beta1.Git{URL: "https://github.com/org/app", Revision: ptr("main")},
+			},
+			Strategy: buildv1beta1.Strategy{Name: "buildah"},
+			ParamValues: []buildv1beta1.ParamValue{
+				{Name: "dockerfile", SingleValue: &buildv1beta1.SingleValue{Value: ptr("Dockerfile")}},
+				{Name: "target", SingleValue: &buildv1beta1.SingleValue{Value: ptr("release")}},
+			},
+			Output: buildv1beta1.Image{Image: "registry.example.com/team-a/app"},
+		},
+	}
+	ociBuild := &buildv1beta1.Build{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle", Namespace: "team-a"},
+		Spec: buildv1beta1.BuildSpec{
+			Source:   &buildv1beta1.Source{Type: buildv1beta1.OCIArtifactType, OCIArtifact: &buildv1beta1.OCIArtifact{Image: "registry.example.com/bundle"}},
+			Strategy: buildv1beta1.Strategy{Name: "buildah"},
+			Output:   buildv1beta1.Image{Image: "registry.example.com/team-a/bundle"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    models.CopyBuildParams
+		problem string
+		check   func(t *testing.T, build *buildv1beta1.Build)
+	}{
+		{
+			name: "copy keeps metadata without last applied configuration",
+			args: models.CopyBuildParams{Name: "app", Namespace: "team-a", TargetNamespace: "team-b"},
+			check: func(t *testing.T, build *buildv1beta1.Build) {
+				if build.Labels["team"] != "a" || build.Annotations["owner"] != "alice" {
+					t.Errorf("metadata = %v %v, want the source labels and annotations", build.Labels, build.Annotations)
+				}
+				if _, ok := build.Annotations[lastAppliedConfigAnnotation]; ok {
+					t.Error("last applied configuration was copied")
+				}
+			},
+		},
+		{
+			name: "revision and output image overrides",
+			args: models.CopyBuildParams{Name: "app", Namespace: "team-a", TargetName: "app-fix", Revision: "fix", OutputImage: "registry.example.com/team-a/app-fix"},
+			check: func(t *testing.T, build *buildv1beta1.Build) {
+				if *build.Spec.Source.Git.Revision != "fix" || build.Spec.Output.Image != "registry.example.com/team-a/app-fix" {
+					t.Errorf("revision %s, output %s, want the overrides", *build.Spec.Source.Git.Revision, build.Spec.Output.Image)
+				}
+			},
+		},
+		{
+			name: "parameters replace existing values and append new ones",
+			args: models.CopyBuildParams{Name: "app", Namespace: "team-a", TargetNamespace: "team-b", Parameters: map[string]string{"dockerfile": "Containerfile", "platform": "linux/arm64", "context": "src"}},
+			check: func(t *testing.T, build *buildv1beta1.Build) {
+				var got []string
+				for _, param := range build.Spec.ParamValues {
+					got = append(got, param.Name+"="+*param.SingleValue.Value)
+				}
+				want := []string{"dockerfile=Containerfile", "target=release", "context=src", "platform=linux/arm64"}
+				if !slices.Equal(got, want) {
+					t.Errorf("paramValues = %v, want %v", got, want)
+				}
+			},
+		},
+		{
+			name:    "revision on a non-Git source",
+			args:    models.CopyBuildParams{Name: "bundle", Namespace: "team-a", TargetName: "bundle-fix", Revision: "fix"},
+			problem: "Revision can only be overridden for Builds with a Git source",
+		},
+		{
+			name:    "same name and namespace",
+			args:    models.CopyBuildParams{Name: "app", Namespace: "team-a", TargetNamespace: "team-a"},
+			problem: "Either target-name or target-namespace must differ from the source Build",
+		},
+		{
+			name:    "missing source",
+			args:    models.CopyBuildParams{Name: "other", Namespace: "team-a", TargetNamespace: "team-b"},
+			problem: "not found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := addTestCluster(t, &Cluster{Clientset: kubefake.NewClientset()}, gitBuild.DeepCopy(), ociBuild.DeepCopy(), &buildv1beta1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "buildah"}})
+
+			result, _, err := CopyBuild(ctx, nil, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			if test.problem != "" {
+				if !result.IsError || !strings.Contains(text, test.problem) {
+					t.Errorf("CopyBuild() = %q, want an error containing %q", text, test.problem)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("CopyBuild() failed: %s", text)
+			}
+
+			targetName := test.args.TargetName
+			if targetName == "" {
+				targetName = test.args.Name
+			}
+			targetNamespace := test.args.TargetNamespace
+			if targetNamespace == "" {
+				targetNamespace = test.args.Namespace
+			}
+			build := &buildv1beta1.Build{}
+			if err := kubeClient(ctx).Get(ctx, client.ObjectKey{Name: targetName, Namespace: targetNamespace}, build); err != nil {
+				t.Fatal(err)
+			}
+			test.check(t, build)
+
+			source := &buildv1beta1.Build{}
+			if err := kubeClient(ctx).Get(ctx, client.ObjectKey{Name: test.args.Name, Namespace: test.args.Namespace}, source); err != nil {
+				t.Fatal(err)
+			}
+			if source.Spec.Source.Git.Revision == nil || *source.Spec.Source.Git.Revision != "main" || len(source.Spec.ParamValues) != 2 {
+				t.Error("CopyBuild modified the source Build")
+			}
+		})
+	}
+}
+
+func TestCheckBuildReferences(t *testing.T) {
+	namespacedKind := buildv1beta1.NamespacedBuildStrategyKind
+	secret := func(name string) runtime.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-b"}}
+	}
+	strategies := []client.Object{
+		&buildv1beta1.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "buildah"}},
+		&buildv1beta1.BuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: "team-b"}},
+	}
+
+	tests := []struct {
+		name    string
+		spec    buildv1beta1.BuildSpec
+		secrets []runtime.Object
+		want    []string
+	}{
+		{
+			name: "everything exists",
+			spec: buildv1beta1.BuildSpec{
+				Strategy: buildv1beta1.Strategy{Name: "buildah"},
+				Output:   buildv1beta1.Image{PushSecret: ptr("registry")},
+			},
+			secrets: []runtime.Object{secret("registry")},
+		},
+		{
+			name: "missing secrets of every kind",
+			spec: buildv1beta1.BuildSpec{
+				Source:   &buildv1beta1.Source{Type: buildv1beta1.GitType, Git: &buildv1beta1.Git{URL: "https://github.com/org/app", CloneSecret: ptr("git")}},
+				Strategy: buildv1beta1.Strategy{Name: "buildah"},
+				ParamValues: []buildv1beta1.ParamValue{
+					{Name: "token", SingleValue: &buildv1beta1.SingleValue{SecretValue: &buildv1beta1.ObjectKeyRef{Name: "param", Key: "token"}}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "NPM_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "npm"}, Key: "token"}}},
+				},
+				Volumes: []buildv1beta1.BuildVolume{
+					{Name: "certs", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "certs"}}},
+				},
+				Output: buildv1beta1.Image{PushSecret: ptr("registry")},
+			},
+			secrets: []runtime.Object{secret("registry")},
+			want: []string{
+				"secret of volume 'certs' 'certs' does not exist in namespace 'team-b'",
+				"source secret 'git' does not exist in namespace 'team-b'",
+				"secret of environment variable 'NPM_TOKEN' 'npm' does not exist in namespace 'team-b'",
+				"secret of parameter 'token' 'param' does not exist in namespace 'team-b'",
+			},
+		},
+		{
+			name: "missing cluster build strategy",
+			spec: buildv1beta1.BuildSpec{Strategy: buildv1beta1.Strategy{Name: "kaniko"}},
+			want: []string{"ClusterBuildStrategy 'kaniko' does not exist"},
+		},
+		{
+			name: "namespaced build strategy",
+			spec: buildv1beta1.BuildSpec{Strategy: buildv1beta1.Strategy{Name: "custom", Kind: &namespacedKind}},
+		},
+		{
+			name: "missing namespaced build strategy",
+			spec: buildv1beta1.BuildSpec{Strategy: buildv1beta1.Strategy{Name: "buildah", Kind: &namespacedKind}},
+			want: []string{"BuildStrategy 'buildah' does not exist in namespace 'team-b'"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := addTestCluster(t, &Cluster{Clientset: kubefake.NewClientset(test.secrets...)}, strategies...)
+			build := &buildv1beta1.Build{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-b"}, Spec: test.spec}
+
+			if got := checkBuildReferences(ctx, build); !slices.Equal(got, test.want) {
+				t.Errorf("checkBuildReferences() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
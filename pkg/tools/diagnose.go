@@ -0,0 +1,330 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const defaultDiagnoseTailLines = 30
+
+type diagnosis struct {
+	cause     string
+	nextSteps []string
+}
+
+func DiagnoseBuildRun(ctx context.Context, req *mcp.CallToolRequest, args models.DiagnoseBuildRunParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Name == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "BuildRun name is required"}},
+		}, nil, nil
+	}
+
+	tailLines := args.TailLines
+	if tailLines <= 0 {
+		tailLines = defaultDiagnoseTailLines
+	}
+
+	buildRun := &buildv1beta1.BuildRun{}
+	if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
+		Namespace: namespace,
+	}, buildRun); err != nil {
+		if errors.IsNotFound(err) {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
+		}
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get buildrun: %v", err)}},
+		}, nil, nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("BuildRun: %s\n", buildRun.Name))
+	result.WriteString(fmt.Sprintf("Namespace: %s\n", buildRun.Namespace))
+	if buildRun.Spec.Build.Name != nil {
+		result.WriteString(fmt.Sprintf("Build: %s\n", *buildRun.Spec.Build.Name))
+	}
+
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+	if condition == nil {
+		result.WriteString("Status: Pending\n")
+		result.WriteString("\nThe BuildRun has not been reconciled yet, there is nothing to diagnose.\n")
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+		}, nil, nil
+	}
+
+	result.WriteString(fmt.Sprintf("Status: %s\n", condition.Status))
+	result.WriteString(fmt.Sprintf("Reason: %s\n", condition.Reason))
+	if condition.Message != "" {
+		result.WriteString(fmt.Sprintf("Message: %s\n", condition.Message))
+	}
+
+	if condition.Status != corev1.ConditionFalse {
+		if condition.Status == corev1.ConditionTrue {
+			result.WriteString("\nThe BuildRun succeeded, there is nothing to diagnose.\n")
+		} else {
+			result.WriteString("\nThe BuildRun is still running, diagnose it again once it has failed.\n")
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+		}, nil, nil
+	}
+
+	reason := condition.Reason
+	var podName, containerName string
+	if buildRun.Status.FailureDetails != nil {
+		result.WriteString("Failure Details:\n")
+		if buildRun.Status.FailureDetails.Reason != "" {
+			reason = buildRun.Status.FailureDetails.Reason
+			result.WriteString(fmt.Sprintf("  Reason: %s\n", buildRun.Status.FailureDetails.Reason))
+		}
+		if buildRun.Status.FailureDetails.Message != "" {
+			result.WriteString(fmt.Sprintf("  Message: %s\n", buildRun.Status.FailureDetails.Message))
+		}
+		if buildRun.Status.FailureDetails.Location != nil {
+			podName = buildRun.Status.FailureDetails.Location.Pod
+			containerName = buildRun.Status.FailureDetails.Location.Container
+			result.WriteString(fmt.Sprintf("  Pod: %s\n", podName))
+			result.WriteString(fmt.Sprintf("  Container: %s\n", containerName))
+		}
+	}
+
+	var build *buildv1beta1.Build
+	if buildRun.Spec.Build.Name != nil {
+		build = &buildv1beta1.Build{}
+		if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+			Name:      *buildRun.Spec.Build.Name,
+			Namespace: namespace,
+		}, build); err != nil {
+			build = nil
+			if errors.IsNotFound(err) {
+				result.WriteString("Build Registration: Build not found\n")
+			} else {
+				result.WriteString(fmt.Sprintf("Build Registration: unavailable (%v)\n", err))
+			}
+		} else if build.Status.Registered != nil {
+			result.WriteString(fmt.Sprintf("Build Registration: %s\n", *build.Status.Registered))
+			if build.Status.Reason != nil {
+				result.WriteString(fmt.Sprintf("  Reason: %s\n", *build.Status.Reason))
+			}
+			if build.Status.Message != nil && *build.Status.Message != "" {
+				result.WriteString(fmt.Sprintf("  Message: %s\n", *build.Status.Message))
+			}
+		}
+	}
+
+	var pod *corev1.Pod
+	if podName == "" {
+		pods, err := kubeClientset(ctx).CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", buildv1beta1.LabelBuildRun, buildRun.Name),
+		})
+		if err == nil && len(pods.Items) > 0 {
+			pod = &pods.Items[0]
+			podName = pod.Name
+		}
+	} else {
+		pod, _ = kubeClientset(ctx).CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	}
+
+	oomKilled := false
+	if pod != nil {
+		for _, status := range pod.Status.ContainerStatuses {
+			terminated := status.State.Terminated
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			if terminated.Reason == "OOMKilled" {
+				oomKilled = true
+			}
+			if containerName == "" {
+				containerName = status.Name
+			}
+		}
+	}
+
+	var logTail string
+	if podName != "" && containerName != "" {
+		logs, err := readContainerLogs(ctx, namespace, podName, containerName, tailLines)
+		if err != nil {
+			toolLogger(ctx).Debug("Failed to read container logs", "namespace", namespace, "pod", podName, "container", containerName, "error", err)
+			logTail = fmt.Sprintf("  unavailable (%v)\n", err)
+		} else {
+			logTail = indent(logs)
+		}
+	}
+
+	if oomKilled {
+		reason = "StepOutOfMemory"
+	}
+
+	diag := diagnoseFailure(reason, condition.Message+"\n"+logTail, build)
+	result.WriteString("\nDiagnosis:\n")
+	result.WriteString(fmt.Sprintf("  Likely Cause: %s\n", diag.cause))
+	result.WriteString("  Next Steps:\n")
+	for _, step := range diag.nextSteps {
+		result.WriteString(fmt.Sprintf("    - %s\n", step))
+	}
+
+	if podName != "" {
+		result.WriteString(fmt.Sprintf("\nEvents (pod %s):\n", podName))
+		events, err := kubeClientset(ctx).CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("involvedObject.name", podName).String(),
+		})
+		switch {
+		case err != nil:
+			result.WriteString(fmt.Sprintf("  unavailable (%v)\n", err))
+		case len(events.Items) == 0:
+			result.WriteString("  none\n")
+		default:
+			for _, event := range events.Items {
+				result.WriteString(fmt.Sprintf("  %s %s: %s\n", event.Type, event.Reason, event.Message))
+			}
+		}
+	}
+
+	if logTail != "" {
+		result.WriteString(fmt.Sprintf("\nLog Tail (container %s, last %d lines):\n", containerName, tailLines))
+		result.WriteString(logTail)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+func readContainerLogs(ctx context.Context, namespace, podName, containerName string, tailLines int64) (string, error) {
+	stream, err := kubeClientset(ctx).CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		TailLines: &tailLines,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return string(logs), nil
+}
+
+func indent(text string) string {
+	var result strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		result.WriteString("  " + line + "\n")
+	}
+	return result.String()
+}
+
+func diagnoseFailure(reason, output string, build *buildv1beta1.Build) diagnosis {
+	if build != nil && build.Status.Reason != nil {
+		switch *build.Status.Reason {
+		case buildv1beta1.SpecOutputSecretRefNotFound, buildv1beta1.UndefinedParameter:
+			reason = string(*build.Status.Reason)
+		}
+	}
+
+	lowerOutput := strings.ToLower(output)
+	switch {
+	case reason == string(buildv1beta1.SpecOutputSecretRefNotFound):
+		return diagnosis{
+			cause: "The push secret referenced in spec.output.pushSecret does not exist",
+			nextSteps: []string{
+				"Create the secret with 'kubectl create secret docker-registry' in the BuildRun namespace",
+				"Or correct spec.output.pushSecret on the Build to reference an existing secret",
+			},
+		}
+	case reason == "StepOutOfMemory", strings.Contains(lowerOutput, "oomkilled"):
+		return diagnosis{
+			cause: "A build step was killed because it exceeded its memory limit",
+			nextSteps: []string{
+				"Raise the memory limit of the failing step in the build strategy",
+				"Reduce the build's memory usage, for example by limiting parallelism in the build tool",
+			},
+		}
+	case reason == "BuildRunTimeout":
+		return diagnosis{
+			cause: "The BuildRun did not finish within its timeout",
+			nextSteps: []string{
+				"Increase the timeout on the Build or BuildRun",
+				"Check the log tail for a step that hangs, such as a dependency download",
+			},
+		}
+	case strings.HasPrefix(reason, "GitAuth"), reason == "GitRemoteRepositoryPrivate", reason == "GitBasicAuthIncomplete",
+		strings.HasPrefix(reason, "GitSSH"), reason == "AuthUnexpectedHTTP",
+		strings.Contains(lowerOutput, "authentication failed for"), strings.Contains(lowerOutput, "could not read username"):
+		return diagnosis{
+			cause: "The source repository could not be cloned because Git authentication failed",
+			nextSteps: []string{
+				"Check that spec.source.git.cloneSecret references a secret with valid credentials",
+				"Use a basic-auth secret for HTTPS URLs and an ssh-auth secret for SSH URLs",
+			},
+		}
+	case reason == string(buildv1beta1.UndefinedParameter):
+		return diagnosis{
+			cause: "A parameter is set that the build strategy does not define",
+			nextSteps: []string{
+				"List the strategy parameters with list_buildstrategies or list_clusterbuildstrategies",
+				"Remove or rename the undefined parameter on the Build or BuildRun",
+			},
+		}
+	case strings.Contains(lowerOutput, "unauthorized"), strings.Contains(lowerOutput, "authentication required"),
+		strings.Contains(lowerOutput, "denied: requested access"):
+		return diagnosis{
+			cause: "The output image could not be pushed, most likely because a push secret is missing or lacks access",
+			nextSteps: []string{
+				"Create a docker-registry secret for the output registry",
+				"Reference it in spec.output.pushSecret on the Build",
+			},
+		}
+	case reason == "BuildNotFound":
+		return diagnosis{
+			cause: "The BuildRun references a Build that does not exist",
+			nextSteps: []string{
+				"Create the Build or correct the build name on the BuildRun",
+			},
+		}
+	case reason == "BuildStrategyNotFound", reason == "ClusterBuildStrategyNotFound":
+		return diagnosis{
+			cause: "The referenced build strategy does not exist",
+			nextSteps: []string{
+				"Check the strategy name and kind on the Build",
+				"List available strategies with list_buildstrategies or list_clusterbuildstrategies",
+			},
+		}
+	case reason == "ServiceAccountNotFound":
+		return diagnosis{
+			cause: "The service account for the BuildRun does not exist",
+			nextSteps: []string{
+				"Create the service account or correct spec.serviceAccount on the BuildRun",
+			},
+		}
+	}
+
+	return diagnosis{
+		cause: fmt.Sprintf("No known pattern matches reason '%s'", reason),
+		nextSteps: []string{
+			"Inspect the log tail and events below for the first error",
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+func TestDiagnoseFailure(t *testing.T) {
+	buildWithReason := func(reason buildv1beta1.BuildReason) *buildv1beta1.Build {
+		return &buildv1beta1.Build{Status: buildv1beta1.BuildStatus{Reason: &reason}}
+	}
+
+	tests := []struct {
+		name   string
+		reason string
+		output string
+		build  *buildv1beta1.Build
+		cause  string
+	}{
+		{"missing push secret", "SpecOutputSecretRefNotFound", "", nil, "push secret referenced in spec.output.pushSecret does not exist"},
+		{"missing push secret on the Build", "Failed", "", buildWithReason(buildv1beta1.SpecOutputSecretRefNotFound), "push secret referenced in spec.output.pushSecret does not exist"},
+		{"step out of memory", "StepOutOfMemory", "", nil, "exceeded its memory limit"},
+		{"OOMKilled in output", "Failed", "container step-build terminated: OOMKilled", nil, "exceeded its memory limit"},
+		{"timeout", "BuildRunTimeout", "", nil, "did not finish within its timeout"},
+		{"Git auth reason", "GitAuthInvalidUserOrPass", "", nil, "Git authentication failed"},
+		{"private repository", "GitRemoteRepositoryPrivate", "", nil, "Git authentication failed"},
+		{"Git SSH reason", "GitSSHAuthSchemeNotSupported", "", nil, "Git authentication failed"},
+		{"Git auth in output", "Failed", "fatal: Authentication failed for 'https://github.com/org/app'", nil, "Git authentication failed"},
+		{"Git username prompt in output", "Failed", "fatal: could not read Username for 'https://github.com'", nil, "Git authentication failed"},
+		{"undefined parameter", "UndefinedParameter", "", nil, "does not define"},
+		{"undefined parameter on the Build", "Failed", "", buildWithReason(buildv1beta1.UndefinedParameter), "does not define"},
+		{"other Build reason ignored", "BuildRunTimeout", "", buildWithReason(buildv1beta1.BuildStrategyNotFound), "did not finish within its timeout"},
+		{"push denied in output", "Failed", "error: denied: requested access to the resource is denied", nil, "could not be pushed"},
+		{"push unauthorized in output", "Failed", "UNAUTHORIZED: authentication required", nil, "could not be pushed"},
+		{"missing Build", "BuildNotFound", "", nil, "references a Build that does not exist"},
+		{"missing cluster strategy", "ClusterBuildStrategyNotFound", "", nil, "build strategy does not exist"},
+		{"missing service account", "ServiceAccountNotFound", "", nil, "service account for the BuildRun does not exist"},
+		{"unknown reason", "Failed", "exit status 1", nil, "No known pattern matches reason 'Failed'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diag := diagnoseFailure(test.reason, test.output, test.build)
+			if !strings.Contains(diag.cause, test.cause) {
+				t.Errorf("cause = %q, want it to contain %q", diag.cause, test.cause)
+			}
+			if len(diag.nextSteps) == 0 {
+				t.Error("no next steps")
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+var diffSections = []struct {
+	field string
+	title string
+}{
+	{"source", "Source"},
+	{"strategy", "Strategy"},
+	{"paramValues", "Parameters"},
+	{"output", "Output"},
+	{"timeout", "Timeout"},
+	{"env", "Env"},
+	{"volumes", "Volumes"},
+	{"retention", "Retention"},
+	{"trigger", "Trigger"},
+}
+
+func DiffBuilds(ctx context.Context, req *mcp.CallToolRequest, args models.DiffBuildsParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Name == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Build name is required"}},
+		}, nil, nil
+	}
+	if (args.OtherName == "") == (args.Manifest == "") {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Exactly one of other-name or manifest must be provided"}},
+		}, nil, nil
+	}
+
+	left := &buildv1beta1.Build{}
+	if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
+		Namespace: namespace,
+	}, left); err != nil {
+		if errors.IsNotFound(err) {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.Name, namespace)}},
+			}, nil, nil
+		}
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
+		}, nil, nil
+	}
+
+	right := &buildv1beta1.Build{}
+	var rightLabel string
+	if args.OtherName != "" {
+		otherNamespace := args.OtherNamespace
+		if otherNamespace == "" {
+			otherNamespace = namespace
+		}
+		if err := kubeClient(ctx).Get(ctx, client.ObjectKey{
+			Name:      args.OtherName,
+			Namespace: otherNamespace,
+		}, right); err != nil {
+			if errors.IsNotFound(err) {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.OtherName, otherNamespace)}},
+				}, nil, nil
+			}
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
+			}, nil, nil
+		}
+		rightLabel = fmt.Sprintf("Build '%s' (namespace '%s')", right.Name, right.Namespace)
+	} else {
+		if err := yaml.Unmarshal([]byte(args.Manifest), right); err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid manifest: %v", err)}},
+			}, nil, nil
+		}
+		if right.Kind != "" && right.Kind != "Build" {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Manifest must be a Build, got '%s'", right.Kind)}},
+			}, nil, nil
+		}
+		rightLabel = "the manifest"
+	}
+
+	leftFields, err := flattenBuildSpec(&left.Spec)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to compare builds: %v", err)}},
+		}, nil, nil
+	}
+	rightFields, err := flattenBuildSpec(&right.Spec)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to compare builds: %v", err)}},
+		}, nil, nil
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Comparing Build '%s' (namespace '%s') with %s:\n", left.Name, left.Namespace, rightLabel))
+
+	differences := 0
+	for _, section := range diffSections {
+		lines := diffFields(leftFields, rightFields, section.field)
+		if len(lines) == 0 {
+			continue
+		}
+		differences += len(lines)
+		result.WriteString(fmt.Sprintf("\n%s:\n", section.title))
+		for _, line := range lines {
+			result.WriteString("  " + line + "\n")
+		}
+	}
+
+	if differences == 0 {
+		result.WriteString("\nNo differences\n")
+	} else {
+		result.WriteString(fmt.Sprintf("\n%d difference(s): '-' only in the first, '+' only in the second, '~' changed\n", differences))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+func diffFields(left, right map[string]string, section string) []string {
+	keys := map[string]struct{}{}
+	for key := range left {
+		if key == section || strings.HasPrefix(key, section+".") || strings.HasPrefix(key, section+"[") {
+			keys[key] = struct{}{}
+		}
+	}
+	for key := range right {
+		if key == section || strings.HasPrefix(key, section+".") || strings.HasPrefix(key, section+"[") {
+			keys[key] = struct{}{}
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	var lines []string
+	for _, key := range sortedKeys {
+		leftValue, inLeft := left[key]
+		rightValue, inRight := right[key]
+		switch {
+		case !inRight:
+			lines = append(lines, fmt.Sprintf("- %s: %s", key, leftValue))
+		case !inLeft:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", key, rightValue))
+		case leftValue != rightValue:
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", key, leftValue, rightValue))
+		}
+	}
+	return lines
+}
+
+// flattenBuildSpec maps every leaf of the spec to a dotted path. Lists of
+// named items, such as paramValues, env and volumes, are keyed by name so
+// that their order does not matter; other lists are keyed by index.
+func flattenBuildSpec(spec *buildv1beta1.BuildSpec) (map[string]string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{}
+	for key, value := range content {
+		flattenValue(key, value, fields)
+	}
+	return fields, nil
+}
+
+func flattenValue(path string, value interface{}, fields map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, item := range v {
+			flattenValue(path+"."+key, item, fields)
+		}
+	case []interface{}:
+		named := len(v) > 0
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				named = false
+				break
+			}
+			if _, ok := m["name"].(string); !ok {
+				named = false
+				break
+			}
+		}
+		for i, item := range v {
+			if !named {
+				flattenValue(fmt.Sprintf("%s[%d]", path, i), item, fields)
+				continue
+			}
+			m := item.(map[string]interface{})
+			itemPath := fmt.Sprintf("%s[%s]", path, m["name"])
+			for key, field := range m {
+				if key != "name" {
+					flattenValue(itemPath+"."+key, field, fields)
+				}
+			}
+		}
+	default:
+		fields[path] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"slices"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDiffFields(t *testing.T) {
+	value := func(s string) *string { return &s }
+	left := &buildv1beta1.BuildSpec{
+		Source:      &buildv1beta1.Source{Type: buildv1beta1.GitType, Git: &buildv1beta1.Git{URL: "https://github.com/org/app", Revision: value("main")}},
+		Output:      buildv1beta1.Image{Image: "registry.example.com/app"},
+		ParamValues: []buildv1beta1.ParamValue{{Name: "a", SingleValue: &buildv1beta1.SingleValue{Value: value("1")}}, {Name: "b", SingleValue: &buildv1beta1.SingleValue{Value: value("2")}}},
+		Env:         []corev1.EnvVar{{Name: "DEBUG", Value: "1"}},
+	}
+	right := &buildv1beta1.BuildSpec{
+		Source:      &buildv1beta1.Source{Type: buildv1beta1.GitType, Git: &buildv1beta1.Git{URL: "https://github.com/org/app"}},
+		Output:      buildv1beta1.Image{Image: "registry.example.com/app:v2"},
+		ParamValues: []buildv1beta1.ParamValue{{Name: "b", SingleValue: &buildv1beta1.SingleValue{Value: value("2")}}, {Name: "a", SingleValue: &buildv1beta1.SingleValue{Value: value("3")}}},
+		Env:         []corev1.EnvVar{{Name: "DEBUG", Value: "1"}, {Name: "TRACE", Value: "1"}},
+	}
+
+	leftFields, err := flattenBuildSpec(left)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rightFields, err := flattenBuildSpec(right)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		section string
+		want    []string
+	}{
+		{"source", []string{"- source.git.revision: main"}},
+		{"output", []string{"~ output.image: registry.example.com/app -> registry.example.com/app:v2"}},
+		{"paramValues", []string{"~ paramValues[a].value: 1 -> 3"}},
+		{"env", []string{"+ env[TRACE].value: 1"}},
+		{"timeout", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.section, func(t *testing.T) {
+			if got := diffFields(leftFields, rightFields, test.section); !slices.Equal(got, test.want) {
+				t.Errorf("diffFields(%s) = %q, want %q", test.section, got, test.want)
+			}
+		})
+	}
+}
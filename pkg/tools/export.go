@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+var serverManagedMetadataFields = []string{
+	"managedFields",
+	"uid",
+	"resourceVersion",
+	"creationTimestamp",
+	"generation",
+	"selfLink",
+	"ownerReferences",
+}
+
+func ExportResource(ctx context.Context, req *mcp.CallToolRequest, args models.ExportResourceParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Name == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Resource name is required"}},
+		}, nil, nil
+	}
+
+	var obj client.Object
+	switch args.Kind {
+	case "Build":
+		obj = &buildv1beta1.Build{}
+	case "BuildRun":
+		obj = &buildv1beta1.BuildRun{}
+	case "BuildStrategy":
+		obj = &buildv1beta1.BuildStrategy{}
+	case "ClusterBuildStrategy":
+		obj = &buildv1beta1.ClusterBuildStrategy{}
+		namespace = ""
+	default:
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Kind must be 'Build', 'BuildRun', 'BuildStrategy' or 'ClusterBuildStrategy'"}},
+		}, nil, nil
+	}
+
+	if args.AsBuild && args.Kind != "BuildRun" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "as-build is only supported for BuildRuns"}},
+		}, nil, nil
+	}
+
+	// Manifests are meant to be committed and applied elsewhere, so they are
+	// read from the API server rather than from a possibly stale cache.
+	if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+		Name:      args.Name,
+		Namespace: namespace,
+	}, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("%s '%s' not found", args.Kind, args.Name)}},
+			}, nil, nil
+		}
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get %s: %v", args.Kind, err)}},
+		}, nil, nil
+	}
+	obj.GetObjectKind().SetGroupVersionKind(buildv1beta1.SchemeGroupVersion.WithKind(args.Kind))
+
+	if args.AsBuild {
+		buildRun := obj.(*buildv1beta1.BuildRun)
+		if buildRun.Spec.Build.Spec == nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("BuildRun '%s' references Build '%s' instead of an inline spec, export that Build instead", buildRun.Name, buildRun.Spec.BuildName())}},
+			}, nil, nil
+		}
+		buildName := args.BuildName
+		if buildName == "" {
+			buildName = buildRun.Name
+		}
+		obj = buildFromBuildRun(buildRun, buildName)
+	}
+
+	manifest, err := cleanManifest(obj)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to export %s: %v", args.Kind, err)}},
+		}, nil, nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: manifest}},
+	}, nil, nil
+}
+
+// buildFromBuildRun turns the inline build spec of a BuildRun into a
+// standalone Build, applying the overrides set on the BuildRun itself.
+func buildFromBuildRun(buildRun *buildv1beta1.BuildRun, name string) *buildv1beta1.Build {
+	build := &buildv1beta1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: buildv1beta1.SchemeGroupVersion.String(),
+			Kind:       "Build",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: buildRun.Namespace,
+		},
+		Spec: *buildRun.Spec.Build.Spec.DeepCopy(),
+	}
+
+	build.Spec.ParamValues = mergeByName(build.Spec.ParamValues, buildRun.Spec.ParamValues, func(param buildv1beta1.ParamValue) string { return param.Name })
+	if buildRun.Spec.Timeout != nil {
+		build.Spec.Timeout = buildRun.Spec.Timeout
+	}
+	if buildRun.Spec.Output != nil {
+		build.Spec.Output = *buildRun.Spec.Output
+	}
+	build.Spec.Env = mergeByName(build.Spec.Env, buildRun.Spec.Env, func(env corev1.EnvVar) string { return env.Name })
+	build.Spec.Volumes = mergeByName(build.Spec.Volumes, buildRun.Spec.Volumes, func(volume buildv1beta1.BuildVolume) string { return volume.Name })
+
+	return build
+}
+
+// mergeByName replaces the items of base that have the name of an item in
+// overrides, the way a BuildRun overrides the values of its Build, and
+// appends the other overrides.
+func mergeByName[T any](base, overrides []T, name func(T) string) []T {
+	for _, override := range overrides {
+		replaced := false
+		for i := range base {
+			if name(base[i]) == name(override) {
+				base[i] = override
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			base = append(base, override)
+		}
+	}
+	return base
+}
+
+func cleanManifest(obj runtime.Object) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		for _, field := range serverManagedMetadataFields {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, lastAppliedConfigAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	manifest, err := yaml.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return string(manifest), nil
+}
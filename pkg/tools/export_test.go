@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuildFromBuildRun(t *testing.T) {
+	value := func(s string) *string { return &s }
+	buildRun := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run-1", Namespace: "team-a"},
+		Spec: buildv1beta1.BuildRunSpec{
+			Build: buildv1beta1.ReferencedBuild{Spec: &buildv1beta1.BuildSpec{
+				ParamValues: []buildv1beta1.ParamValue{{Name: "dockerfile", SingleValue: &buildv1beta1.SingleValue{Value: value("Dockerfile")}}},
+				Env:         []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}, {Name: "CGO_ENABLED", Value: "0"}},
+				Volumes:     []buildv1beta1.BuildVolume{{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			}},
+			ParamValues: []buildv1beta1.ParamValue{{Name: "dockerfile", SingleValue: &buildv1beta1.SingleValue{Value: value("Containerfile")}}},
+			Env:         []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=mod"}, {Name: "DEBUG", Value: "1"}},
+			Volumes:     []buildv1beta1.BuildVolume{{Name: "cache", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}}},
+		},
+	}
+
+	build := buildFromBuildRun(buildRun, "exported")
+
+	if build.Name != "exported" || build.Namespace != "team-a" {
+		t.Errorf("metadata = %s/%s, want team-a/exported", build.Namespace, build.Name)
+	}
+	if len(build.Spec.ParamValues) != 1 || *build.Spec.ParamValues[0].SingleValue.Value != "Containerfile" {
+		t.Errorf("paramValues = %+v, want the BuildRun value only", build.Spec.ParamValues)
+	}
+	wantEnv := []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=mod"}, {Name: "CGO_ENABLED", Value: "0"}, {Name: "DEBUG", Value: "1"}}
+	if !reflect.DeepEqual(build.Spec.Env, wantEnv) {
+		t.Errorf("env = %+v, want %+v", build.Spec.Env, wantEnv)
+	}
+	if len(build.Spec.Volumes) != 1 || build.Spec.Volumes[0].ConfigMap == nil {
+		t.Errorf("volumes = %+v, want the BuildRun volume only", build.Spec.Volumes)
+	}
+	if buildRun.Spec.Build.Spec.Env[0].Value != "-mod=vendor" {
+		t.Error("buildFromBuildRun modified the BuildRun")
+	}
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var shipwrightResources = []string{"builds", "buildruns", "buildstrategies", "clusterbuildstrategies"}
+
+// ClusterReadiness is the readiness of one configured cluster. Err is nil when
+// the cluster is ready.
+type ClusterReadiness struct {
+	Name string
+	Err  error
+}
+
+// CheckReadiness checks, for every configured cluster, that its API server is
+// reachable and serves the Shipwright Build resources.
+func CheckReadiness(ctx context.Context) []ClusterReadiness {
+	var readiness []ClusterReadiness
+	for _, name := range clusterNames() {
+		readiness = append(readiness, ClusterReadiness{Name: name, Err: checkShipwrightResources(ctx, clusters[name])})
+	}
+	return readiness
+}
+
+func checkShipwrightResources(ctx context.Context, cluster *Cluster) error {
+	body, err := cluster.Clientset.Discovery().RESTClient().Get().AbsPath("/apis", buildv1beta1.SchemeGroupVersion.String()).Do(ctx).Raw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("%s API is not served, Shipwright Build CRDs are not installed", buildv1beta1.SchemeGroupVersion)
+		}
+		return err
+	}
+	var resourceList metav1.APIResourceList
+	if err := json.Unmarshal(body, &resourceList); err != nil {
+		return err
+	}
+
+	served := map[string]bool{}
+	for _, resource := range resourceList.APIResources {
+		served[resource.Name] = true
+	}
+	var missing []string
+	for _, resource := range shipwrightResources {
+		if !served[resource] {
+			missing = append(missing, resource)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s does not serve %s", buildv1beta1.SchemeGroupVersion, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const defaultHistoryLimit = 10
+
+func GetBuildHistory(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildHistoryParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Name == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Build name is required"}},
+		}, nil, nil
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := kubeClient(ctx).List(ctx, buildRunList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{buildv1beta1.LabelBuild: args.Name},
+	); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list buildruns: %v", err)}},
+		}, nil, nil
+	}
+
+	if len(buildRunList.Items) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No buildruns found for Build '%s' in namespace '%s'", args.Name, namespace)}},
+		}, nil, nil
+	}
+
+	buildRuns := buildRunList.Items
+	sortBuildRuns(buildRuns, "creation", true)
+	if len(buildRuns) > limit {
+		buildRuns = buildRuns[:limit]
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Build: %s\n", args.Name))
+	result.WriteString(fmt.Sprintf("Namespace: %s\n", namespace))
+	result.WriteString(fmt.Sprintf("Last %d BuildRun(s), newest first:\n", len(buildRuns)))
+
+	for i := range buildRuns {
+		buildRun := &buildRuns[i]
+		line := fmt.Sprintf("  %s: %s", buildRun.Name, buildRunStatus(buildRun))
+		if reason := buildRunFailureReason(buildRun); reason != "" {
+			line += fmt.Sprintf(" (%s)", reason)
+		}
+		if buildRun.Status.StartTime != nil && buildRun.Status.CompletionTime != nil {
+			line += fmt.Sprintf(", duration %s", buildRun.Status.CompletionTime.Sub(buildRun.Status.StartTime.Time).Round(time.Second))
+		}
+		if commit := buildRunCommit(buildRun); commit != "" {
+			line += fmt.Sprintf(", commit %s", shortCommit(commit))
+		}
+		if buildRun.Status.Output != nil && buildRun.Status.Output.Digest != "" {
+			line += fmt.Sprintf(", digest %s", buildRun.Status.Output.Digest)
+		}
+		line += fmt.Sprintf(", created %s", buildRun.CreationTimestamp.Format("2006-01-02 15:04:05"))
+		result.WriteString(line + "\n")
+	}
+
+	writeFailureOnset(&result, buildRuns)
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+// writeFailureOnset reports the failing streak at the head of buildRuns, which
+// must be sorted newest first, and what changed since the last success.
+func writeFailureOnset(result *strings.Builder, buildRuns []buildv1beta1.BuildRun) {
+	firstFailure := -1
+	lastSuccess := -1
+	for i := range buildRuns {
+		status := buildRunStatus(&buildRuns[i])
+		if status == "Succeeded" {
+			lastSuccess = i
+			break
+		}
+		if status == "Failed" {
+			firstFailure = i
+		}
+	}
+
+	if firstFailure == -1 {
+		return
+	}
+
+	failures := 0
+	for i := 0; i <= firstFailure; i++ {
+		if buildRunStatus(&buildRuns[i]) == "Failed" {
+			failures++
+		}
+	}
+
+	failed := &buildRuns[firstFailure]
+	result.WriteString("\nFailing Since:\n")
+	result.WriteString(fmt.Sprintf("  First Failure: %s (created %s), %d failure(s) since\n", failed.Name, failed.CreationTimestamp.Format("2006-01-02 15:04:05"), failures))
+
+	if lastSuccess == -1 {
+		result.WriteString("  No successful BuildRun in this window, increase the limit to find the last success\n")
+		return
+	}
+
+	succeeded := &buildRuns[lastSuccess]
+	result.WriteString(fmt.Sprintf("  Last Success: %s (created %s)\n", succeeded.Name, succeeded.CreationTimestamp.Format("2006-01-02 15:04:05")))
+
+	changes := 0
+	if before, after := buildRunCommit(succeeded), buildRunCommit(failed); before != after {
+		result.WriteString(fmt.Sprintf("  Commit Changed: %s -> %s\n", shortCommit(before), shortCommit(after)))
+		changes++
+	}
+	if before, after := succeeded.Labels[buildv1beta1.LabelBuildGeneration], failed.Labels[buildv1beta1.LabelBuildGeneration]; before != after {
+		result.WriteString(fmt.Sprintf("  Build Generation Changed: %s -> %s\n", before, after))
+		changes++
+	}
+
+	beforeParams, afterParams := buildRunParams(succeeded), buildRunParams(failed)
+	names := map[string]struct{}{}
+	for name := range beforeParams {
+		names[name] = struct{}{}
+	}
+	for name := range afterParams {
+		names[name] = struct{}{}
+	}
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	for _, name := range sortedNames {
+		before, hadBefore := beforeParams[name]
+		after, hasAfter := afterParams[name]
+		switch {
+		case !hadBefore:
+			result.WriteString(fmt.Sprintf("  Parameter Added: %s=%s\n", name, after))
+		case !hasAfter:
+			result.WriteString(fmt.Sprintf("  Parameter Removed: %s (was %s)\n", name, before))
+		case before != after:
+			result.WriteString(fmt.Sprintf("  Parameter Changed: %s: %s -> %s\n", name, before, after))
+		default:
+			continue
+		}
+		changes++
+	}
+
+	if changes == 0 {
+		result.WriteString("  No commit, Build generation or parameter change between the last success and the first failure\n")
+	}
+}
+
+func buildRunCommit(buildRun *buildv1beta1.BuildRun) string {
+	if buildRun.Status.Source != nil && buildRun.Status.Source.Git != nil {
+		return buildRun.Status.Source.Git.CommitSha
+	}
+	return ""
+}
+
+func shortCommit(commit string) string {
+	if commit == "" {
+		return "unknown"
+	}
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+func buildRunParams(buildRun *buildv1beta1.BuildRun) map[string]string {
+	params := map[string]string{}
+	if buildRun.Status.BuildSpec != nil {
+		for _, param := range buildRun.Status.BuildSpec.ParamValues {
+			params[param.Name] = paramValueString(param)
+		}
+	}
+	for _, param := range buildRun.Spec.ParamValues {
+		params[param.Name] = paramValueString(param)
+	}
+	return params
+}
+
+func paramValueString(param buildv1beta1.ParamValue) string {
+	if param.SingleValue != nil {
+		return singleValueString(*param.SingleValue)
+	}
+	values := make([]string, 0, len(param.Values))
+	for _, value := range param.Values {
+		values = append(values, singleValueString(value))
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func singleValueString(value buildv1beta1.SingleValue) string {
+	switch {
+	case value.Value != nil:
+		return *value.Value
+	case value.ConfigMapValue != nil:
+		return fmt.Sprintf("configMap %s/%s", value.ConfigMapValue.Name, value.ConfigMapValue.Key)
+	case value.SecretValue != nil:
+		return fmt.Sprintf("secret %s/%s", value.SecretValue.Name, value.SecretValue.Key)
+	}
+	return ""
+}
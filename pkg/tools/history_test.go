@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func historyRun(name string, status corev1.ConditionStatus, commit, generation string, params map[string]string) buildv1beta1.BuildRun {
+	buildRun := buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{buildv1beta1.LabelBuildGeneration: generation}},
+		Status: buildv1beta1.BuildRunStatus{
+			Conditions: buildv1beta1.Conditions{{Type: buildv1beta1.Succeeded, Status: status}},
+			Source:     &buildv1beta1.SourceResult{Git: &buildv1beta1.GitSourceResult{CommitSha: commit}},
+		},
+	}
+	for name, value := range params {
+		buildRun.Spec.ParamValues = append(buildRun.Spec.ParamValues, buildv1beta1.ParamValue{Name: name, SingleValue: &buildv1beta1.SingleValue{Value: &value}})
+	}
+	return buildRun
+}
+
+func TestWriteFailureOnset(t *testing.T) {
+	const (
+		failed    = corev1.ConditionFalse
+		succeeded = corev1.ConditionTrue
+	)
+
+	tests := []struct {
+		name      string
+		buildRuns []buildv1beta1.BuildRun
+		want      []string
+		wantEmpty bool
+	}{
+		{
+			name: "passing build",
+			buildRuns: []buildv1beta1.BuildRun{
+				historyRun("run-2", succeeded, "bbbbbbbbbb", "1", nil),
+				historyRun("run-1", failed, "aaaaaaaaaa", "1", nil),
+			},
+			wantEmpty: true,
+		},
+		{
+			name: "commit change",
+			buildRuns: []buildv1beta1.BuildRun{
+				historyRun("run-3", failed, "cccccccccc", "1", nil),
+				historyRun("run-2", failed, "cccccccccc", "1", nil),
+				historyRun("run-1", succeeded, "aaaaaaaaaa", "1", nil),
+			},
+			want: []string{"First Failure: run-2", "2 failure(s) since", "Last Success: run-1", "Commit Changed: aaaaaaa -> ccccccc"},
+		},
+		{
+			name: "generation and parameter changes",
+			buildRuns: []buildv1beta1.BuildRun{
+				historyRun("run-2", failed, "aaaaaaaaaa", "2", map[string]string{"target": "prod", "cache": "on"}),
+				historyRun("run-1", succeeded, "aaaaaaaaaa", "1", map[string]string{"target": "dev", "debug": "1"}),
+			},
+			want: []string{"Build Generation Changed: 1 -> 2", "Parameter Added: cache=on", "Parameter Removed: debug (was 1)", "Parameter Changed: target: dev -> prod"},
+		},
+		{
+			name: "no change",
+			buildRuns: []buildv1beta1.BuildRun{
+				historyRun("run-2", failed, "aaaaaaaaaa", "1", nil),
+				historyRun("run-1", succeeded, "aaaaaaaaaa", "1", nil),
+			},
+			want: []string{"No commit, Build generation or parameter change"},
+		},
+		{
+			name: "no success in window",
+			buildRuns: []buildv1beta1.BuildRun{
+				historyRun("run-2", failed, "aaaaaaaaaa", "1", nil),
+				historyRun("run-1", failed, "aaaaaaaaaa", "1", nil),
+			},
+			want: []string{"First Failure: run-1", "No successful BuildRun in this window"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result strings.Builder
+			writeFailureOnset(&result, test.buildRuns)
+			if test.wantEmpty && result.Len() > 0 {
+				t.Errorf("writeFailureOnset() = %q, want no output", result.String())
+			}
+			for _, want := range test.want {
+				if !strings.Contains(result.String(), want) {
+					t.Errorf("writeFailureOnset() = %q, want it to contain %q", result.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,429 @@
+package tools
+
+import (
+	"archive/tar"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// LocalSourceTool is left out in HTTP mode unless explicitly enabled, since
+// it reads from the file system of the server.
+const LocalSourceTool = "create_buildrun_from_local_source"
+
+var ignoreFilenames = []string{".gitignore", ".shpignore"}
+
+var bundleRegistry string
+
+var bundleRegistryInsecure bool
+
+func SetBundleRegistry(registry string, insecure bool) {
+	bundleRegistry = strings.TrimSuffix(registry, "/")
+	bundleRegistryInsecure = insecure
+}
+
+var localSourceRoots []string
+
+// SetLocalSourceRoots limits the directories that can be packed to those
+// below one of roots.
+func SetLocalSourceRoots(roots []string) {
+	localSourceRoots = nil
+	for _, root := range roots {
+		if resolved, err := filepath.EvalSymlinks(root); err == nil {
+			root = resolved
+		}
+		localSourceRoots = append(localSourceRoots, filepath.Clean(root))
+	}
+}
+
+func CreateBuildRunFromLocalSource(ctx context.Context, req *mcp.CallToolRequest, args models.CreateBuildRunFromLocalSourceParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	if args.Directory == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Directory is required"}},
+		}, nil, nil
+	}
+	strategy := args.Strategy
+	if strategy == "" {
+		strategy = defaultStrategy
+	}
+	if args.BuildName == "" && (strategy == "" || args.OutputImage == "") {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "Either build-name or strategy and output-image must be provided"}},
+		}, nil, nil
+	}
+
+	var timeout *metav1.Duration
+	if args.Timeout != "" {
+		duration, err := time.ParseDuration(args.Timeout)
+		if err != nil {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid timeout duration: %v", err)}},
+			}, nil, nil
+		}
+		timeout = &metav1.Duration{Duration: duration}
+	}
+
+	directory, err := filepath.Abs(args.Directory)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid directory: %v", err)}},
+		}, nil, nil
+	}
+	directory, err = filepath.EvalSymlinks(directory)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Directory '%s' does not exist or is not a directory", args.Directory)}},
+		}, nil, nil
+	}
+	if info, err := os.Stat(directory); err != nil || !info.IsDir() {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Directory '%s' does not exist or is not a directory", directory)}},
+		}, nil, nil
+	}
+	if !withinLocalSourceRoots(directory) {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Directory '%s' is outside of the allowed workspace roots: %s", directory, strings.Join(localSourceRoots, ", "))}},
+		}, nil, nil
+	}
+
+	if bundleRegistry == "" {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "No bundle registry is configured, set SHIPWRIGHT_BUNDLE_REGISTRY"}},
+		}, nil, nil
+	}
+	bundleImage := args.BundleImage
+	if bundleImage == "" {
+		repository := args.BuildName
+		if repository == "" {
+			repository = "buildrun"
+		}
+		bundleImage = fmt.Sprintf("%s/%s-source-bundle:%d", bundleRegistry, repository, time.Now().Unix())
+	}
+
+	var buildSpec *buildv1beta1.BuildSpec
+	var buildLabels map[string]string
+	if args.BuildName != "" {
+		build := &buildv1beta1.Build{}
+		if err := apiReader(ctx).Get(ctx, client.ObjectKey{
+			Name:      args.BuildName,
+			Namespace: namespace,
+		}, build); err != nil {
+			if errors.IsNotFound(err) {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Build '%s' not found in namespace '%s'", args.BuildName, namespace)}},
+				}, nil, nil
+			}
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to get build: %v", err)}},
+			}, nil, nil
+		}
+		buildSpec = build.Spec.DeepCopy()
+		buildLabels = map[string]string{
+			buildv1beta1.LabelBuild:           build.Name,
+			buildv1beta1.LabelBuildGeneration: strconv.FormatInt(build.Generation, 10),
+		}
+	} else {
+		buildSpec = &buildv1beta1.BuildSpec{
+			Strategy: buildv1beta1.Strategy{
+				Name: strategy,
+			},
+			Output: buildv1beta1.Image{
+				Image: args.OutputImage,
+			},
+		}
+		strategyKind := args.StrategyKind
+		if strategyKind == "" {
+			strategyKind = defaultStrategyKind
+		}
+		kind := buildv1beta1.BuildStrategyKind(strategyKind)
+		buildSpec.Strategy.Kind = &kind
+	}
+
+	nameOptions := []name.Option{}
+	if bundleRegistryInsecure {
+		nameOptions = append(nameOptions, name.Insecure)
+	}
+	ref, err := name.ParseReference(bundleImage, nameOptions...)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid bundle image: %v", err)}},
+		}, nil, nil
+	}
+	if !underBundleRegistry(ref, nameOptions) {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Bundle image '%s' is not under the configured bundle registry '%s'", bundleImage, bundleRegistry)}},
+		}, nil, nil
+	}
+
+	toolLogger(ctx).Info("Pushing source bundle", "directory", directory, "image", ref.String())
+	digest, err := packAndPush(ctx, ref, directory)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to push source bundle: %v", err)}},
+		}, nil, nil
+	}
+
+	var contextDir *string
+	if args.ContextDir != "" {
+		contextDir = &args.ContextDir
+	} else if buildSpec.Source != nil {
+		contextDir = buildSpec.Source.ContextDir
+	}
+	buildSpec.Source = &buildv1beta1.Source{
+		Type:       buildv1beta1.OCIArtifactType,
+		ContextDir: contextDir,
+		OCIArtifact: &buildv1beta1.OCIArtifact{
+			Image: digest.String(),
+		},
+	}
+
+	buildRun := &buildv1beta1.BuildRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Labels:    buildLabels,
+		},
+		Spec: buildv1beta1.BuildRunSpec{
+			Build: buildv1beta1.ReferencedBuild{
+				Spec: buildSpec,
+			},
+			Timeout: timeout,
+		},
+	}
+
+	if args.Name != "" {
+		buildRun.Name = args.Name
+	} else if args.BuildName != "" {
+		buildRun.GenerateName = args.BuildName + "-local-"
+	} else {
+		buildRun.GenerateName = "buildrun-local-"
+	}
+
+	if args.ServiceAccount != "" {
+		buildRun.Spec.ServiceAccount = &args.ServiceAccount
+	}
+
+	if len(args.Parameters) > 0 {
+		for name, value := range args.Parameters {
+			param := buildv1beta1.ParamValue{
+				Name: name,
+				SingleValue: &buildv1beta1.SingleValue{
+					Value: &value,
+				},
+			}
+			buildRun.Spec.ParamValues = append(buildRun.Spec.ParamValues, param)
+		}
+	}
+
+	if err := kubeClient(ctx).Create(ctx, buildRun); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to create buildrun: %v", err)}},
+		}, nil, nil
+	}
+	toolLogger(ctx).Info("Created BuildRun from local source", "namespace", namespace, "name", buildRun.Name, "bundle", digest.String())
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Pushed source bundle '%s' and created BuildRun '%s' in namespace '%s'", digest.String(), buildRun.Name, namespace)}},
+	}, nil, nil
+}
+
+func withinLocalSourceRoots(directory string) bool {
+	for _, root := range localSourceRoots {
+		rel, err := filepath.Rel(root, directory)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// underBundleRegistry reports whether ref is a repository below the
+// configured bundle registry, comparing normalized names so that for example
+// docker.io and index.docker.io are treated alike.
+func underBundleRegistry(ref name.Reference, options []name.Option) bool {
+	registry, err := name.NewRepository(bundleRegistry+"/bundle", options...)
+	if err != nil {
+		return false
+	}
+	prefix := strings.TrimSuffix(registry.Name(), "bundle")
+	return strings.HasPrefix(ref.Context().Name(), prefix)
+}
+
+// packAndPush streams the tar of directory into the pushed layer instead of
+// holding it in memory. The opener can be called more than once, for the
+// digest and for the upload, and packs the directory again each time.
+func packAndPush(ctx context.Context, ref name.Reference, directory string) (name.Digest, error) {
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(packDirectory(writer, directory))
+		}()
+		return reader, nil
+	})
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	image, err := mutate.Time(empty.Image, time.Unix(0, 0))
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	image, err = mutate.AppendLayers(image, layer)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	hash, err := image.Digest()
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	if err := remote.Write(ref, image, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)); err != nil {
+		return name.Digest{}, err
+	}
+
+	return ref.Context().Digest(hash.String()), nil
+}
+
+// packDirectory writes a tar stream of directory to w. The .git directory,
+// symlinks and everything matched by a .gitignore or .shpignore file are left
+// out.
+func packDirectory(w io.Writer, directory string) error {
+	tw := tar.NewWriter(w)
+	var patterns []gitignore.Pattern
+
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." {
+			if d.IsDir() && d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			if gitignore.NewMatcher(patterns).Match(strings.Split(rel, string(filepath.Separator)), d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if d.IsDir() {
+			var domain []string
+			if rel != "." {
+				domain = strings.Split(rel, string(filepath.Separator))
+			}
+			for _, filename := range ignoreFilenames {
+				filePatterns, err := readIgnorePatterns(filepath.Join(path, filename), domain)
+				if err != nil {
+					return err
+				}
+				patterns = append(patterns, filePatterns...)
+			}
+			if rel == "." {
+				return nil
+			}
+		}
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && !info.Mode().IsRegular() {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tw.Close()
+}
+
+func readIgnorePatterns(path string, domain []string) ([]gitignore.Pattern, error) {
+	if info, err := os.Lstat(path); err == nil && !info.Mode().IsRegular() {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, gitignore.ParsePattern(line, domain))
+		}
+	}
+	return patterns, scanner.Err()
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPackDirectory(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "plain files",
+			files: map[string]string{"main.go": "", "pkg/util.go": ""},
+			want:  []string{"main.go", "pkg", "pkg/util.go"},
+		},
+		{
+			name:  "git directory",
+			files: map[string]string{"main.go": "", ".git/HEAD": ""},
+			want:  []string{"main.go"},
+		},
+		{
+			name:  "gitignore",
+			files: map[string]string{".gitignore": "*.log\nbin/\n", "main.go": "", "build.log": "", "bin/app": ""},
+			want:  []string{".gitignore", "main.go"},
+		},
+		{
+			name:  "shpignore with comments",
+			files: map[string]string{".shpignore": "# local only\n\nsecrets.env\n", "main.go": "", "secrets.env": ""},
+			want:  []string{".shpignore", "main.go"},
+		},
+		{
+			name:  "nested ignore file applies below its directory",
+			files: map[string]string{"docs/.gitignore": "*.html\n", "docs/index.html": "", "index.html": ""},
+			want:  []string{"docs", "docs/.gitignore", "index.html"},
+		},
+		{
+			name:  "negation",
+			files: map[string]string{".gitignore": "*.txt\n!keep.txt\n", "drop.txt": "", "keep.txt": ""},
+			want:  []string{".gitignore", "keep.txt"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directory := t.TempDir()
+			for name, content := range test.files {
+				path := filepath.Join(directory, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got := packedNames(t, directory)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("packed %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPackDirectorySkipsSymlinks(t *testing.T) {
+	directory := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(outside, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "main.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(directory, "link")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(filepath.Dir(outside), filepath.Join(directory, "linked-dir")); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := packedNames(t, directory), []string{"main.go"}; !slices.Equal(got, want) {
+		t.Errorf("packed %v, want %v", got, want)
+	}
+}
+
+func TestWithinLocalSourceRoots(t *testing.T) {
+	root := t.TempDir()
+	SetLocalSourceRoots([]string{root})
+	t.Cleanup(func() { SetLocalSourceRoots(nil) })
+
+	tests := []struct {
+		directory string
+		want      bool
+	}{
+		{root, true},
+		{filepath.Join(root, "app"), true},
+		{filepath.Dir(root), false},
+		{root + "-other", false},
+		{filepath.Join(root, "..", filepath.Base(root)+"-other"), false},
+	}
+	for _, test := range tests {
+		if got := withinLocalSourceRoots(test.directory); got != test.want {
+			t.Errorf("withinLocalSourceRoots(%s) = %v, want %v", test.directory, got, test.want)
+		}
+	}
+}
+
+func packedNames(t *testing.T, directory string) []string {
+	t.Helper()
+	var bundle bytes.Buffer
+	if err := packDirectory(&bundle, directory); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	reader := tar.NewReader(&bundle)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+	}
+	slices.Sort(names)
+	return names
+}
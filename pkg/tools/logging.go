@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/shipwright-io/build/server/pkg/audit"
+)
+
+const loggerName = "shipwright-build"
+
+var logToClient bool
+
+// SetLogToClient forwards the log records of tool calls to the calling MCP
+// client as logging notifications, at the level the client requested.
+func SetLogToClient(enabled bool) {
+	logToClient = enabled
+}
+
+type loggerKey struct{}
+
+// toolLogger returns the logger of the current tool call, which carries the
+// tool name and request ID.
+func toolLogger(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// withLogging assigns every call of the tool a request ID and logs its start
+// and outcome with a logger that the handler can reach through toolLogger.
+func withLogging[In any](name string, handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		logHandler := slog.Default().Handler()
+		if logToClient && req != nil && req.Session != nil {
+			logHandler = teeHandler{logHandler, mcp.NewLoggingHandler(req.Session, &mcp.LoggingHandlerOptions{LoggerName: loggerName})}
+		}
+		attributes := []any{"tool", name, "requestId", uuid.NewString()}
+		if req != nil && req.Session != nil && req.Session.ID() != "" {
+			attributes = append(attributes, "session", req.Session.ID())
+		}
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+			attributes = append(attributes, "traceId", spanContext.TraceID().String())
+		}
+		logger := slog.New(logHandler).With(attributes...)
+		ctx = context.WithValue(ctx, loggerKey{}, logger)
+
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			if content, err := json.Marshal(args); err == nil {
+				var arguments map[string]any
+				if json.Unmarshal(content, &arguments) == nil {
+					logger.DebugContext(ctx, "Tool call started", "arguments", audit.Redact(arguments))
+				}
+			}
+		}
+
+		start := time.Now()
+		result, out, err := handler(ctx, req, args)
+		duration := time.Since(start)
+
+		switch {
+		case err != nil:
+			logger.ErrorContext(ctx, "Tool call failed", "duration", duration, "error", err)
+		case result != nil && result.IsError:
+			message := ""
+			if len(result.Content) > 0 {
+				if text, ok := result.Content[0].(*mcp.TextContent); ok {
+					message = text.Text
+				}
+			}
+			logger.WarnContext(ctx, "Tool call returned an error", "duration", duration, "message", message)
+		default:
+			logger.InfoContext(ctx, "Tool call succeeded", "duration", duration)
+		}
+		return result, out, err
+	}
+}
+
+// teeHandler passes every record to each of its handlers that is enabled for
+// the record's level.
+type teeHandler []slog.Handler
+
+func (h teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h teeHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, record.Level) {
+			errs = append(errs, handler.Handle(ctx, record.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (h teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
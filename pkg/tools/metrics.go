@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/metrics"
+)
+
+// withMetrics counts and times every call of the tool, and classifies failed
+// calls by the Kubernetes requests they made.
+func withMetrics[In any](name string, handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		start := time.Now()
+		stats := &metrics.CallStats{}
+
+		result, out, err := handler(metrics.WithCallStats(ctx, stats), req, args)
+
+		metrics.ToolCalls.WithLabelValues(name).Inc()
+		metrics.ToolDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		switch {
+		case err != nil:
+			metrics.ToolErrors.WithLabelValues(name, metrics.ErrorClassInternal).Inc()
+		case result != nil && result.IsError:
+			metrics.ToolErrors.WithLabelValues(name, stats.ErrorClass()).Inc()
+		}
+		return result, out, err
+	}
+}
+
+// countingClient counts the BuildRuns created through the server by
+// namespace and strategy.
+type countingClient struct {
+	client.Client
+}
+
+func (c countingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if buildRun, ok := obj.(*buildv1beta1.BuildRun); ok && err == nil {
+		metrics.BuildRunsCreated.WithLabelValues(buildRun.Namespace, c.buildRunStrategy(ctx, buildRun)).Inc()
+	}
+	return err
+}
+
+func (c countingClient) buildRunStrategy(ctx context.Context, buildRun *buildv1beta1.BuildRun) string {
+	if buildRun.Spec.Build.Spec != nil {
+		return buildRun.Spec.Build.Spec.Strategy.Name
+	}
+	build := &buildv1beta1.Build{}
+	if err := c.Client.Get(ctx, client.ObjectKey{Name: buildRun.Spec.BuildName(), Namespace: buildRun.Namespace}, build); err != nil {
+		return "unknown"
+	}
+	return build.Spec.Strategy.Name
+}
@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// sessionNamespaces holds the default namespace set by each MCP session for
+// each cluster, since a namespace of one cluster rarely exists in another.
+var sessionNamespaces = struct {
+	sync.Mutex
+	namespaces map[*mcp.ServerSession]map[string]string
+}{namespaces: map[*mcp.ServerSession]map[string]string{}}
+
+func currentClusterName(ctx context.Context) string {
+	if cluster := currentCluster(ctx); cluster != nil {
+		return cluster.Name
+	}
+	return ""
+}
+
+// resolveNamespace is the single place where tools pick the namespace to
+// work in. An explicit namespace wins, followed by the default set for the
+// MCP session and cluster with set_default_namespace, the configured default
+// namespace,
+// and the namespace of the cluster's kubeconfig context or of the pod the
+// server runs in.
+func resolveNamespace(ctx context.Context, req *mcp.CallToolRequest, namespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	if req != nil && req.Session != nil {
+		sessionNamespaces.Lock()
+		namespace = sessionNamespaces.namespaces[req.Session][currentClusterName(ctx)]
+		sessionNamespaces.Unlock()
+		if namespace != "" {
+			return namespace
+		}
+	}
+	if defaultNamespace != "" {
+		return defaultNamespace
+	}
+	if cluster := currentCluster(ctx); cluster != nil && cluster.Namespace != "" {
+		return cluster.Namespace
+	}
+	return metav1.NamespaceDefault
+}
+
+func SetDefaultNamespace(ctx context.Context, req *mcp.CallToolRequest, args models.SetDefaultNamespaceParams) (*mcp.CallToolResult, any, error) {
+	if args.Namespace != "" {
+		if errs := validation.IsDNS1123Label(args.Namespace); len(errs) > 0 {
+			return &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid namespace '%s': %s", args.Namespace, strings.Join(errs, ", "))}},
+			}, nil, nil
+		}
+		if !namespaceAllowed(args.Namespace) {
+			return namespacePolicyResult(args.Namespace), nil, nil
+		}
+	}
+	if req == nil || req.Session == nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "No MCP session to set the default namespace for"}},
+		}, nil, nil
+	}
+
+	cluster := currentClusterName(ctx)
+	sessionNamespaces.Lock()
+	namespaces, tracked := sessionNamespaces.namespaces[req.Session]
+	if !tracked {
+		namespaces = map[string]string{}
+		sessionNamespaces.namespaces[req.Session] = namespaces
+	}
+	if args.Namespace == "" {
+		delete(namespaces, cluster)
+	} else {
+		namespaces[cluster] = args.Namespace
+	}
+	sessionNamespaces.Unlock()
+
+	if !tracked {
+		session := req.Session
+		go func() {
+			_ = session.Wait()
+			sessionNamespaces.Lock()
+			delete(sessionNamespaces.namespaces, session)
+			sessionNamespaces.Unlock()
+		}()
+	}
+
+	if args.Namespace == "" {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Cleared the session default namespace for context '%s', tools now default to namespace '%s'", cluster, resolveNamespace(ctx, req, ""))}},
+		}, nil, nil
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Tools called without a namespace on context '%s' now use namespace '%s' for the rest of this session", cluster, args.Namespace)}},
+	}, nil, nil
+}
+
+type namespaceScope struct {
+	namespace     string
+	namespaces    []string
+	allNamespaces bool
+}
+
+func (s namespaceScope) multiple() bool {
+	return s.allNamespaces || len(s.namespaces) > 0
+}
+
+// validate rejects scopes that set more than one of namespace, namespaces and
+// all-namespaces, since only one of them could be listed.
+func (s namespaceScope) validate() error {
+	set := 0
+	for _, ok := range []bool{s.namespace != "", len(s.namespaces) > 0, s.allNamespaces} {
+		if ok {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("set only one of namespace, namespaces and all-namespaces")
+	}
+	return nil
+}
+
+// listInScope lists objects in every namespace of the scope. A cluster-wide
+// List is tried first for all namespaces; if that is forbidden, each namespace
+// is listed on its own. Namespaces the caller may not list are skipped and
+// returned by name instead of failing the whole call. For all namespaces,
+// only objects in namespaces allowed by the server policy are returned.
+func listInScope[T client.ObjectList](ctx context.Context, scope namespaceScope, newList func() T, listOpts []client.ListOption) ([]T, []string, error) {
+	if !scope.multiple() {
+		list := newList()
+		if err := kubeClient(ctx).List(ctx, list, append([]client.ListOption{client.InNamespace(scope.namespace)}, listOpts...)...); err != nil {
+			return nil, nil, err
+		}
+		return []T{list}, nil, nil
+	}
+
+	namespaces := scope.namespaces
+	if scope.allNamespaces {
+		list := newList()
+		err := kubeClient(ctx).List(ctx, list, listOpts...)
+		if err == nil {
+			if err := filterAllowedNamespaces(list); err != nil {
+				return nil, nil, err
+			}
+			return []T{list}, nil, nil
+		}
+		if !errors.IsForbidden(err) {
+			return nil, nil, err
+		}
+
+		namespaceList, err := kubeClientset(ctx).CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("not allowed to list across all namespaces and failed to list namespaces: %w", err)
+		}
+		namespaces = nil
+		for _, namespace := range namespaceList.Items {
+			if namespaceAllowed(namespace.Name) {
+				namespaces = append(namespaces, namespace.Name)
+			}
+		}
+	}
+
+	var lists []T
+	var skipped []string
+	for _, namespace := range namespaces {
+		list := newList()
+		if err := kubeClient(ctx).List(ctx, list, append([]client.ListOption{client.InNamespace(namespace)}, listOpts...)...); err != nil {
+			if errors.IsForbidden(err) {
+				skipped = append(skipped, namespace)
+				continue
+			}
+			return nil, nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, skipped, nil
+}
+
+func sortByNamespace[T any](items []T, namespace func(*T) string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return namespace(&items[i]) < namespace(&items[j])
+	})
+}
+
+func writeNamespaceHeader(result *strings.Builder, namespace string, current *string) {
+	if namespace == *current {
+		return
+	}
+	*current = namespace
+	result.WriteString(fmt.Sprintf("== Namespace: %s ==\n", namespace))
+}
+
+func writeSkippedNamespaces(result *strings.Builder, skipped []string) {
+	if len(skipped) == 0 {
+		return
+	}
+	result.WriteString(fmt.Sprintf("\nSkipped namespaces (forbidden): %s\n", strings.Join(skipped, ", ")))
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+func TestNamespaceScopeValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope namespaceScope
+		valid bool
+	}{
+		{"default namespace", namespaceScope{}, true},
+		{"namespace", namespaceScope{namespace: "team-a"}, true},
+		{"namespaces", namespaceScope{namespaces: []string{"team-a", "team-b"}}, true},
+		{"all namespaces", namespaceScope{allNamespaces: true}, true},
+		{"namespace and namespaces", namespaceScope{namespace: "team-a", namespaces: []string{"team-b"}}, false},
+		{"namespace and all namespaces", namespaceScope{namespace: "team-a", allNamespaces: true}, false},
+		{"namespaces and all namespaces", namespaceScope{namespaces: []string{"team-b"}, allNamespaces: true}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.scope.validate(); (err == nil) != test.valid {
+				t.Errorf("validate() = %v, want valid %v", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestListToolsRejectSeveralScopes(t *testing.T) {
+	ctx := context.Background()
+	builds, _, _ := ListBuilds(ctx, nil, models.ListBuildsParams{Namespace: "team-a", Namespaces: []string{"team-b"}})
+	buildRuns, _, _ := ListBuildRuns(ctx, nil, models.ListBuildRunsParams{Namespace: "team-a", AllNamespaces: true})
+	strategies, _, _ := ListBuildStrategies(ctx, nil, models.ListBuildStrategiesParams{Namespace: "team-a", Namespaces: []string{"team-b"}})
+
+	for name, result := range map[string]*mcp.CallToolResult{"list_builds": builds, "list_buildruns": buildRuns, "list_buildstrategies": strategies} {
+		if !result.IsError || !strings.Contains(result.Content[0].(*mcp.TextContent).Text, "set only one of namespace, namespaces and all-namespaces") {
+			t.Errorf("%s accepted namespace together with namespaces or all-namespaces", name)
+		}
+	}
+}
+
+func TestSessionDefaultNamespacePerCluster(t *testing.T) {
+	_, serverTransport := mcp.NewInMemoryTransports()
+	session, err := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil).Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	req := &mcp.CallToolRequest{Session: session}
+
+	dev := addTestCluster(t, &Cluster{Name: "dev", Namespace: "dev-default"})
+	prod := addTestCluster(t, &Cluster{Name: "prod", Namespace: "prod-default"})
+
+	steps := []struct {
+		name      string
+		ctx       context.Context
+		namespace string
+		wantDev   string
+		wantProd  string
+	}{
+		{"set on dev", dev, "team-a", "team-a", "prod-default"},
+		{"set on prod", prod, "team-b", "team-a", "team-b"},
+		{"clear on dev", dev, "", "dev-default", "team-b"},
+	}
+	for _, step := range steps {
+		result, _, err := SetDefaultNamespace(step.ctx, req, models.SetDefaultNamespaceParams{Namespace: step.namespace})
+		if err != nil || result.IsError {
+			t.Fatalf("%s: SetDefaultNamespace() = %+v, %v", step.name, result, err)
+		}
+		if got := resolveNamespace(dev, req, ""); got != step.wantDev {
+			t.Errorf("%s: dev namespace = %s, want %s", step.name, got, step.wantDev)
+		}
+		if got := resolveNamespace(prod, req, ""); got != step.wantProd {
+			t.Errorf("%s: prod namespace = %s, want %s", step.name, got, step.wantProd)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/shipwright-io/build/server/pkg/audit"
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// permission is a Kubernetes action a tool performs. A tool still works
+// without an optional permission, with less output.
+type permission struct {
+	access   authorizationv1.ResourceAttributes
+	optional bool
+}
+
+func shipwright(verb, resource string) permission {
+	return permission{access: authorizationv1.ResourceAttributes{Verb: verb, Group: buildv1beta1.SchemeGroupVersion.Group, Resource: resource}}
+}
+
+func core(verb, resource, subresource string) permission {
+	return permission{access: authorizationv1.ResourceAttributes{Verb: verb, Resource: resource, Subresource: subresource}}
+}
+
+func optional(p permission) permission {
+	p.optional = true
+	return p
+}
+
+// listNamespaces is needed to list across all namespaces when the caller may
+// not list a resource cluster-wide.
+var listNamespaces = core("list", "namespaces", "")
+
+// clusterScopedResources are reviewed without a namespace.
+var clusterScopedResources = map[string]bool{
+	"clusterbuildstrategies": true,
+	"namespaces":             true,
+}
+
+// registeredTools holds the tools registered with the server, in registration
+// order, so that permissions are only checked for tools the caller can call.
+var registeredTools []Definition
+
+// toolPermissions returns the permissions of a registered tool, including
+// creating the Events that the audit sink records calls of mutating tools as.
+func toolPermissions(definition Definition) []permission {
+	permissions := definition.permissions
+	if !definition.ReadOnly && audit.WritesEvents(auditSink) {
+		permissions = append(slices.Clone(permissions), optional(core("create", "events", "")))
+	}
+	return permissions
+}
+
+func CheckPermissions(ctx context.Context, req *mcp.CallToolRequest, args models.CheckPermissionsParams) (*mcp.CallToolResult, any, error) {
+	cluster := currentCluster(ctx)
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	checkCtx, cancel := context.WithTimeout(ctx, 2*clusterCheckTimeout)
+	defer cancel()
+	reviews, err := reviewPermissions(checkCtx, cluster, namespace, registeredPermissions(false))
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to review access: %v", err)}},
+		}, nil, nil
+	}
+
+	var usable, limited, unusable []string
+	for _, definition := range registeredTools {
+		var missingRequired, missingOptional []string
+		for _, p := range toolPermissions(definition) {
+			if reviews[describeAccess(p.access)] {
+				continue
+			}
+			if p.optional {
+				missingOptional = append(missingOptional, describeAccess(p.access))
+			} else {
+				missingRequired = append(missingRequired, describeAccess(p.access))
+			}
+		}
+		switch {
+		case len(missingRequired) > 0:
+			unusable = append(unusable, fmt.Sprintf("%s: missing %s", definition.Name, strings.Join(missingRequired, ", ")))
+		case len(missingOptional) > 0:
+			limited = append(limited, fmt.Sprintf("%s: missing %s", definition.Name, strings.Join(missingOptional, ", ")))
+		default:
+			usable = append(usable, definition.Name)
+		}
+	}
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Permissions in namespace '%s' (context %s):\n", namespace, cluster.Name))
+	writeToolList(&result, "Usable Tools", usable)
+	writeToolList(&result, "Limited Tools (work with less output)", limited)
+	writeToolList(&result, "Unusable Tools", unusable)
+
+	result.WriteString("\nChecked Permissions:\n")
+	for _, access := range registeredPermissions(false) {
+		status := "denied"
+		if reviews[describeAccess(access)] {
+			status = "allowed"
+		}
+		result.WriteString(fmt.Sprintf("  [%s] %s\n", status, describeAccess(access)))
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+func writeToolList(result *strings.Builder, title string, tools []string) {
+	if len(tools) == 0 {
+		return
+	}
+	result.WriteString(fmt.Sprintf("\n%s:\n", title))
+	for _, tool := range tools {
+		result.WriteString(fmt.Sprintf("  - %s\n", tool))
+	}
+}
+
+// registeredPermissions returns the distinct permissions of the registered
+// tools in registration order, only the ones the tools cannot work
+// without when requiredOnly is set.
+func registeredPermissions(requiredOnly bool) []authorizationv1.ResourceAttributes {
+	var accesses []authorizationv1.ResourceAttributes
+	seen := map[string]bool{}
+	for _, definition := range registeredTools {
+		for _, p := range toolPermissions(definition) {
+			if (requiredOnly && p.optional) || seen[describeAccess(p.access)] {
+				continue
+			}
+			seen[describeAccess(p.access)] = true
+			accesses = append(accesses, p.access)
+		}
+	}
+	return accesses
+}
+
+// reviewPermissions reviews every access in namespace and returns whether it
+// is allowed, keyed by describeAccess.
+func reviewPermissions(ctx context.Context, cluster *Cluster, namespace string, accesses []authorizationv1.ResourceAttributes) (map[string]bool, error) {
+	reviews := map[string]bool{}
+	for _, access := range accesses {
+		allowed, err := reviewAccess(ctx, cluster, namespace, access)
+		if err != nil {
+			return nil, err
+		}
+		reviews[describeAccess(access)] = allowed
+	}
+	return reviews, nil
+}
+
+// reviewAccess asks the API server whether the caller may perform the action
+// described by attributes in namespace. Cluster-scoped resources ignore
+// the namespace.
+func reviewAccess(ctx context.Context, cluster *Cluster, namespace string, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	if !clusterScopedResources[attributes.Resource] {
+		attributes.Namespace = namespace
+	}
+	review, err := cluster.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+func describeAccess(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Subresource != "" {
+		resource += "/" + attributes.Subresource
+	}
+	return attributes.Verb + " " + resource
+}
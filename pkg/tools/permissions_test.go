@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/shipwright-io/build/server/pkg/audit"
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// registerTools makes the named tools the registered ones for the test.
+func registerTools(t *testing.T, names ...string) {
+	t.Helper()
+	previous := registeredTools
+	t.Cleanup(func() { registeredTools = previous })
+	registeredTools = nil
+	for _, name := range names {
+		index := slices.IndexFunc(Definitions, func(d Definition) bool { return d.Name == name })
+		if index < 0 {
+			t.Fatalf("unknown tool %s", name)
+		}
+		registeredTools = append(registeredTools, Definitions[index])
+	}
+}
+
+func describeAccesses(accesses []authorizationv1.ResourceAttributes) []string {
+	var described []string
+	for _, access := range accesses {
+		described = append(described, describeAccess(access))
+	}
+	return described
+}
+
+func TestRegisteredPermissions(t *testing.T) {
+	tests := []struct {
+		name         string
+		tools        []string
+		requiredOnly bool
+		eventSink    bool
+		want         []string
+	}{
+		{
+			name:  "distinct permissions in registration order",
+			tools: []string{"get_build", "list_builds", "delete_build"},
+			want:  []string{"get builds", "list builds", "list namespaces", "list buildruns", "delete builds", "delete buildruns"},
+		},
+		{
+			name:         "required only",
+			tools:        []string{"get_build", "list_builds", "delete_build"},
+			requiredOnly: true,
+			want:         []string{"get builds", "list builds", "list buildruns", "delete builds"},
+		},
+		{
+			name:  "tools without permissions",
+			tools: []string{"list_clusters", "set_default_namespace"},
+		},
+		{
+			name:      "event sink adds events for mutating tools",
+			tools:     []string{"get_build", "create_build"},
+			eventSink: true,
+			want:      []string{"get builds", "create builds", "create events"},
+		},
+		{
+			name:      "event sink ignores read-only tools",
+			tools:     []string{"get_build"},
+			eventSink: true,
+			want:      []string{"get builds"},
+		},
+		{
+			name:         "audit events are optional",
+			tools:        []string{"create_build"},
+			requiredOnly: true,
+			eventSink:    true,
+			want:         []string{"create builds"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registerTools(t, test.tools...)
+			if test.eventSink {
+				SetAuditSink(audit.NewEventSink(func(string) kubernetes.Interface { return nil }))
+			} else {
+				SetAuditSink(audit.NewJSONLinesSink(io.Discard))
+			}
+			t.Cleanup(func() { SetAuditSink(nil) })
+
+			if got := describeAccesses(registeredPermissions(test.requiredOnly)); !slices.Equal(got, test.want) {
+				t.Errorf("registeredPermissions() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// accessReviewer answers SelfSubjectAccessReviews from a set of allowed
+// accesses and records the namespace of every review.
+type accessReviewer struct {
+	allowed    map[string]bool
+	namespaces map[string]string
+}
+
+func (r *accessReviewer) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+	attributes := review.Spec.ResourceAttributes
+	r.namespaces[describeAccess(*attributes)] = attributes.Namespace
+	review.Status.Allowed = r.allowed[describeAccess(*attributes)]
+	return true, review, nil
+}
+
+func TestCheckPermissions(t *testing.T) {
+	registerTools(t, "get_build", "list_builds", "list_clusterbuildstrategies", "delete_build", "list_clusters")
+
+	reviewer := &accessReviewer{
+		allowed: map[string]bool{
+			"get builds":                  true,
+			"list builds":                 true,
+			"list clusterbuildstrategies": true,
+			"list buildruns":              true,
+			"delete builds":               false,
+			"list namespaces":             false,
+			"delete buildruns":            false,
+		},
+		namespaces: map[string]string{},
+	}
+	clientset := kubefake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", reviewer.react)
+	ctx := addTestCluster(t, &Cluster{Clientset: clientset})
+
+	result, _, err := CheckPermissions(ctx, nil, models.CheckPermissionsParams{Namespace: "team-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("CheckPermissions() failed: %s", text)
+	}
+
+	sections := []struct {
+		title string
+		lines []string
+	}{
+		{"Usable Tools:", []string{"  - get_build", "  - list_clusterbuildstrategies", "  - list_clusters"}},
+		{"Limited Tools (work with less output):", []string{"  - list_builds: missing list namespaces"}},
+		{"Unusable Tools:", []string{"  - delete_build: missing delete builds"}},
+	}
+	for _, section := range sections {
+		want := section.title + "\n" + strings.Join(section.lines, "\n") + "\n"
+		if !strings.Contains(text, want) {
+			t.Errorf("output does not contain\n%s\ngot\n%s", want, text)
+		}
+	}
+	for _, line := range []string{"  [allowed] list buildruns", "  [denied] delete buildruns", "  [denied] list namespaces"} {
+		if !strings.Contains(text, line) {
+			t.Errorf("output does not contain %q", line)
+		}
+	}
+
+	if len(reviewer.namespaces) != len(reviewer.allowed) {
+		t.Errorf("reviewed %d permissions, want %d", len(reviewer.namespaces), len(reviewer.allowed))
+	}
+	for access, namespace := range reviewer.namespaces {
+		want := "team-a"
+		if access == "list clusterbuildstrategies" || access == "list namespaces" {
+			want = ""
+		}
+		if namespace != want {
+			t.Errorf("%s reviewed in namespace %q, want %q", access, namespace, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+var allowedNamespaces []string
+
+// SetAllowedNamespaces restricts every tool to namespaces matching one of the
+// glob patterns. An empty list allows all namespaces.
+func SetAllowedNamespaces(patterns []string) {
+	allowedNamespaces = patterns
+}
+
+func namespaceAllowed(namespace string) bool {
+	if len(allowedNamespaces) == 0 {
+		return true
+	}
+	for _, pattern := range allowedNamespaces {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func namespacePolicyResult(namespace string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Namespace '%s' is not allowed by the server policy, allowed namespaces: %s", namespace, strings.Join(allowedNamespaces, ", "))}},
+	}
+}
+
+// withNamespacePolicy rejects calls naming a namespace outside the allowed
+// namespaces before the handler makes any Kubernetes call. The namespaces are
+// those the params declare, with the namespace the call resolves to when it
+// works in the default namespace.
+func withNamespacePolicy[In models.NamespacedParams](handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		if len(allowedNamespaces) > 0 {
+			for _, namespace := range requestedNamespaces(ctx, req, args) {
+				if !namespaceAllowed(namespace) {
+					return namespacePolicyResult(namespace), nil, nil
+				}
+			}
+		}
+		return handler(ctx, req, args)
+	}
+}
+
+func requestedNamespaces(ctx context.Context, req *mcp.CallToolRequest, args models.NamespacedParams) []string {
+	namespaces, defaultNamespace := args.RequestedNamespaces()
+	if defaultNamespace {
+		namespaces = append(namespaces, resolveNamespace(ctx, req, ""))
+	}
+	return namespaces
+}
+
+// filterAllowedNamespaces removes the items of list that are in namespaces
+// outside the allowed namespaces.
+func filterAllowedNamespaces(list client.ObjectList) error {
+	if len(allowedNamespaces) == 0 {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	allowed := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		object, err := meta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		if namespaceAllowed(object.GetNamespace()) {
+			allowed = append(allowed, item)
+		}
+	}
+	return meta.SetList(list, allowed)
+}
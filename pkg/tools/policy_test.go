@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+func TestRequestedNamespaces(t *testing.T) {
+	defaultNamespace = "team-a"
+	t.Cleanup(func() { defaultNamespace = "" })
+
+	tests := []struct {
+		name string
+		args models.NamespacedParams
+		want []string
+	}{
+		{"explicit namespace", models.GetBuildParams{Namespace: "team-b"}, []string{"team-b"}},
+		{"default namespace", models.GetBuildParams{}, []string{"team-a"}},
+		{"namespaces ignore the default", models.ListBuildsParams{Namespaces: []string{"team-b", "team-c"}}, []string{"team-b", "team-c"}},
+		{"namespace and namespaces", models.ListBuildRunsParams{Namespace: "team-b", Namespaces: []string{"team-c"}}, []string{"team-b", "team-c"}},
+		{"all namespaces", models.ListBuildStrategiesParams{AllNamespaces: true}, nil},
+		{"copy target", models.CopyBuildParams{TargetNamespace: "team-c"}, []string{"team-c", "team-a"}},
+		{"diff other", models.DiffBuildsParams{Namespace: "team-b", OtherNamespace: "team-c"}, []string{"team-b", "team-c"}},
+		{"cluster-scoped", models.ListClusterBuildStrategiesParams{}, nil},
+		{"export namespaced kind", models.ExportResourceParams{Kind: "BuildStrategy"}, []string{"team-a"}},
+		{"export cluster-scoped kind", models.ExportResourceParams{Kind: "ClusterBuildStrategy", Namespace: "team-b"}, nil},
+		{"no cluster", models.ListClustersParams{}, nil},
+		{"set session default", models.SetDefaultNamespaceParams{Namespace: "team-b"}, []string{"team-b"}},
+		{"clear session default", models.SetDefaultNamespaceParams{}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := requestedNamespaces(context.Background(), nil, test.args)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("requestedNamespaces() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNamespacePolicyIgnoresClusterScopedExport(t *testing.T) {
+	SetAllowedNamespaces([]string{"team-*"})
+	t.Cleanup(func() { SetAllowedNamespaces(nil) })
+
+	handler := withNamespacePolicy(func(ctx context.Context, req *mcp.CallToolRequest, args models.ExportResourceParams) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	})
+
+	tests := []struct {
+		name    string
+		args    models.ExportResourceParams
+		allowed bool
+	}{
+		{"cluster build strategy with denied default", models.ExportResourceParams{Kind: "ClusterBuildStrategy", Name: "buildah"}, true},
+		{"build strategy with denied default", models.ExportResourceParams{Kind: "BuildStrategy", Name: "buildah"}, false},
+		{"build strategy in allowed namespace", models.ExportResourceParams{Kind: "BuildStrategy", Name: "buildah", Namespace: "team-a"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, _, err := handler(context.Background(), nil, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError == test.allowed {
+				t.Errorf("IsError = %v, want %v", result.IsError, !test.allowed)
+			}
+		})
+	}
+}
+
+func TestNamespacePolicy(t *testing.T) {
+	SetAllowedNamespaces([]string{"team-*", "shared"})
+	t.Cleanup(func() { SetAllowedNamespaces(nil) })
+
+	handler := withNamespacePolicy(func(ctx context.Context, req *mcp.CallToolRequest, args models.CopyBuildParams) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	})
+
+	tests := []struct {
+		name    string
+		args    models.CopyBuildParams
+		allowed bool
+	}{
+		{"matching pattern", models.CopyBuildParams{Namespace: "team-a"}, true},
+		{"exact name", models.CopyBuildParams{Namespace: "shared", TargetNamespace: "team-b"}, true},
+		{"denied namespace", models.CopyBuildParams{Namespace: "kube-system"}, false},
+		{"denied target", models.CopyBuildParams{Namespace: "team-a", TargetNamespace: "prod"}, false},
+		{"denied default", models.CopyBuildParams{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, _, err := handler(context.Background(), nil, test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.IsError == test.allowed {
+				t.Errorf("IsError = %v, want %v", result.IsError, !test.allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const tektonGroup = "tekton.dev"
+
+// checkResult is one item of the checklist reported by check_cluster.
+type checkResult struct {
+	name        string
+	passed      bool
+	details     string
+	remediation string
+}
+
+func CheckCluster(ctx context.Context, req *mcp.CallToolRequest, args models.CheckClusterParams) (*mcp.CallToolResult, any, error) {
+	cluster := currentCluster(ctx)
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	checkCtx, cancel := context.WithTimeout(ctx, 2*clusterCheckTimeout)
+	defer cancel()
+	results := runClusterChecks(checkCtx, cluster, namespace)
+
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("Cluster Check: %s (%s)\n", cluster.Name, cluster.Host))
+	result.WriteString(fmt.Sprintf("Namespace: %s\n\n", namespace))
+
+	failed := 0
+	for _, check := range results {
+		status := "PASS"
+		if !check.passed {
+			status = "FAIL"
+			failed++
+		}
+		result.WriteString(fmt.Sprintf("[%s] %s: %s\n", status, check.name, check.details))
+		if !check.passed && check.remediation != "" {
+			result.WriteString(fmt.Sprintf("       Fix: %s\n", check.remediation))
+		}
+	}
+	result.WriteString(fmt.Sprintf("\nResult: %d passed, %d failed\n", len(results)-failed, failed))
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+// Preflight runs the cluster checks against every configured cluster and
+// logs the failed ones with their remediation. It does not stop the server,
+// since a cluster may become usable after startup.
+func Preflight(ctx context.Context) {
+	for _, name := range clusterNames() {
+		cluster := clusters[name]
+		checkCtx, cancel := context.WithTimeout(ctx, 2*clusterCheckTimeout)
+		namespace := resolveNamespace(withCluster(checkCtx, cluster), nil, "")
+		failed := 0
+		for _, check := range runClusterChecks(checkCtx, cluster, namespace) {
+			if !check.passed {
+				failed++
+				slog.Warn("Preflight check failed", "context", name, "check", check.name, "details", check.details, "remediation", check.remediation)
+			}
+		}
+		cancel()
+		if failed == 0 {
+			slog.Info("Preflight checks passed", "context", name, "namespace", namespace)
+		}
+	}
+}
+
+func runClusterChecks(ctx context.Context, cluster *Cluster, namespace string) []checkResult {
+	serverVersion, err := kubernetesVersion(ctx, cluster)
+	if err != nil {
+		return []checkResult{{
+			name:        "Kubernetes API reachable",
+			details:     err.Error(),
+			remediation: fmt.Sprintf("Check the server URL %s, network access and the credentials of context '%s'", cluster.Host, cluster.Name),
+		}}
+	}
+
+	return []checkResult{
+		{name: "Kubernetes API reachable", passed: true, details: serverVersion},
+		checkShipwrightAPI(ctx, cluster),
+		checkShipwrightController(ctx, cluster),
+		checkTekton(ctx, cluster),
+		checkRequiredAccess(ctx, cluster, namespace),
+	}
+}
+
+func checkShipwrightAPI(ctx context.Context, cluster *Cluster) checkResult {
+	check := checkResult{name: "Shipwright Build CRDs"}
+	group, err := apiGroup(ctx, cluster, buildv1beta1.SchemeGroupVersion.Group)
+	if err != nil {
+		check.details = err.Error()
+		check.remediation = "Install Shipwright Build v0.13.0 or later, see https://shipwright.io/docs/"
+		return check
+	}
+
+	versions := servedVersions(group)
+	if err := checkShipwrightResources(ctx, cluster); err != nil {
+		check.details = fmt.Sprintf("served versions %s, but %v", strings.Join(versions, ", "), err)
+		check.remediation = "Upgrade Shipwright Build to v0.13.0 or later, which serves the v1beta1 API"
+		return check
+	}
+
+	check.passed = true
+	check.details = fmt.Sprintf("builds, buildruns, buildstrategies and clusterbuildstrategies served, versions %s", strings.Join(versions, ", "))
+	return check
+}
+
+func checkShipwrightController(ctx context.Context, cluster *Cluster) checkResult {
+	check := checkResult{name: "Shipwright Build controller ready"}
+	deployment, err := cluster.Clientset.AppsV1().Deployments(shipwrightControllerNamespace).Get(ctx, shipwrightControllerDeployment, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		check.details = fmt.Sprintf("Deployment %s/%s not found", shipwrightControllerNamespace, shipwrightControllerDeployment)
+		check.remediation = "Install Shipwright Build, or verify the controller manually if it runs in another namespace"
+		return check
+	case errors.IsForbidden(err):
+		check.details = fmt.Sprintf("not allowed to get Deployment %s/%s", shipwrightControllerNamespace, shipwrightControllerDeployment)
+		check.remediation = fmt.Sprintf("Grant get on deployments in namespace '%s', or verify the controller manually", shipwrightControllerNamespace)
+		return check
+	case err != nil:
+		check.details = err.Error()
+		return check
+	}
+
+	check.details = fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+	if deployment.Status.ReadyReplicas == 0 {
+		check.remediation = fmt.Sprintf("Inspect the controller with 'kubectl -n %s describe deployment %s' and its logs", shipwrightControllerNamespace, shipwrightControllerDeployment)
+		return check
+	}
+	check.passed = true
+	return check
+}
+
+func checkTekton(ctx context.Context, cluster *Cluster) checkResult {
+	check := checkResult{name: "Tekton Pipelines installed"}
+	group, err := apiGroup(ctx, cluster, tektonGroup)
+	if err != nil {
+		check.details = err.Error()
+		check.remediation = "Install Tekton Pipelines, which Shipwright Build uses to run BuildRuns, see https://tekton.dev/docs/installation/pipelines/"
+		return check
+	}
+
+	check.passed = true
+	check.details = fmt.Sprintf("%s served, versions %s", tektonGroup, strings.Join(servedVersions(group), ", "))
+	return check
+}
+
+func checkRequiredAccess(ctx context.Context, cluster *Cluster, namespace string) checkResult {
+	check := checkResult{name: fmt.Sprintf("Permissions in namespace '%s'", namespace)}
+	accesses := registeredPermissions(true)
+	reviews, err := reviewPermissions(ctx, cluster, namespace, accesses)
+	if err != nil {
+		check.details = fmt.Sprintf("failed to review access: %v", err)
+		check.remediation = "Grant create on selfsubjectaccessreviews.authorization.k8s.io, which every authenticated user has by default"
+		return check
+	}
+	var missing []string
+	for _, access := range accesses {
+		if !reviews[describeAccess(access)] {
+			missing = append(missing, describeAccess(access))
+		}
+	}
+
+	if len(missing) > 0 {
+		check.details = fmt.Sprintf("missing %s", strings.Join(missing, ", "))
+		check.remediation = fmt.Sprintf("Ask a cluster administrator to grant %s in namespace '%s', or run check_permissions to see which tools are affected", strings.Join(missing, ", "), namespace)
+		return check
+	}
+	check.passed = true
+	check.details = "all permissions required by the registered tools granted"
+	return check
+}
+
+func apiGroup(ctx context.Context, cluster *Cluster, name string) (*metav1.APIGroup, error) {
+	body, err := cluster.Clientset.Discovery().RESTClient().Get().AbsPath("/apis", name).Do(ctx).Raw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("API group %s is not served", name)
+		}
+		return nil, err
+	}
+	group := &metav1.APIGroup{}
+	if err := json.Unmarshal(body, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func servedVersions(group *metav1.APIGroup) []string {
+	versions := make([]string, 0, len(group.Versions))
+	for _, version := range group.Versions {
+		versions = append(versions, version.Version)
+	}
+	return versions
+}
@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// fakeAPIServer serves the discovery, Deployment and SelfSubjectAccessReview
+// requests that the cluster checks make.
+type fakeAPIServer struct {
+	unreachable    bool
+	withoutCRDs    bool
+	resources      []string
+	controllerCode int
+	readyReplicas  int32
+	withoutTekton  bool
+	deniedAccesses []string
+}
+
+func (s *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch path := r.URL.Path; {
+	case path == "/version":
+		if s.unreachable {
+			http.Error(w, "etcd unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, map[string]string{"gitVersion": "v1.31.0"})
+	case path == "/apis/shipwright.io" && !s.withoutCRDs:
+		writeJSON(w, metav1.APIGroup{Name: "shipwright.io", Versions: []metav1.GroupVersionForDiscovery{{Version: "v1alpha1"}, {Version: "v1beta1"}}})
+	case path == "/apis/shipwright.io/v1beta1" && !s.withoutCRDs:
+		list := metav1.APIResourceList{GroupVersion: "shipwright.io/v1beta1"}
+		for _, resource := range s.resources {
+			list.APIResources = append(list.APIResources, metav1.APIResource{Name: resource})
+		}
+		writeJSON(w, list)
+	case path == "/apis/tekton.dev" && !s.withoutTekton:
+		writeJSON(w, metav1.APIGroup{Name: "tekton.dev", Versions: []metav1.GroupVersionForDiscovery{{Version: "v1"}}})
+	case path == "/apis/apps/v1/namespaces/shipwright-build/deployments/shipwright-build-controller":
+		if s.controllerCode != http.StatusOK {
+			http.Error(w, http.StatusText(s.controllerCode), s.controllerCode)
+			return
+		}
+		writeJSON(w, appsv1.Deployment{Status: appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: s.readyReplicas}})
+	case path == "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews":
+		var review authorizationv1.SelfSubjectAccessReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		review.Status.Allowed = !slices.Contains(s.deniedAccesses, describeAccess(*review.Spec.ResourceAttributes))
+		writeJSON(w, review)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestCheckCluster(t *testing.T) {
+	registerTools(t, "list_builds", "create_buildrun")
+	allResources := []string{"builds", "buildruns", "buildstrategies", "clusterbuildstrategies"}
+
+	tests := []struct {
+		name   string
+		server fakeAPIServer
+		want   []string
+	}{
+		{
+			name:   "ready cluster",
+			server: fakeAPIServer{resources: allResources, controllerCode: http.StatusOK, readyReplicas: 1},
+			want: []string{
+				"[PASS] Kubernetes API reachable: v1.31.0",
+				"[PASS] Shipwright Build CRDs: builds, buildruns, buildstrategies and clusterbuildstrategies served, versions v1alpha1, v1beta1",
+				"[PASS] Shipwright Build controller ready: 1/1 replicas ready",
+				"[PASS] Tekton Pipelines installed: tekton.dev served, versions v1",
+				"[PASS] Permissions in namespace 'team-a': all permissions required by the registered tools granted",
+				"Result: 5 passed, 0 failed",
+			},
+		},
+		{
+			name:   "unreachable API server",
+			server: fakeAPIServer{unreachable: true},
+			want: []string{
+				"[FAIL] Kubernetes API reachable:",
+				"Fix: Check the server URL",
+				"Result: 0 passed, 1 failed",
+			},
+		},
+		{
+			name:   "Shipwright not installed",
+			server: fakeAPIServer{withoutCRDs: true, controllerCode: http.StatusNotFound},
+			want: []string{
+				"[FAIL] Shipwright Build CRDs: API group shipwright.io is not served",
+				"[FAIL] Shipwright Build controller ready: Deployment shipwright-build/shipwright-build-controller not found",
+				"Result: 3 passed, 2 failed",
+			},
+		},
+		{
+			name:   "old Shipwright release",
+			server: fakeAPIServer{resources: []string{"builds", "buildruns"}, controllerCode: http.StatusOK, readyReplicas: 1},
+			want: []string{
+				"[FAIL] Shipwright Build CRDs: served versions v1alpha1, v1beta1, but shipwright.io/v1beta1 does not serve buildstrategies, clusterbuildstrategies",
+				"Fix: Upgrade Shipwright Build to v0.13.0 or later",
+			},
+		},
+		{
+			name:   "controller not ready",
+			server: fakeAPIServer{resources: allResources, controllerCode: http.StatusOK},
+			want: []string{
+				"[FAIL] Shipwright Build controller ready: 0/1 replicas ready",
+				"Fix: Inspect the controller with 'kubectl -n shipwright-build describe deployment shipwright-build-controller'",
+			},
+		},
+		{
+			name:   "controller not visible",
+			server: fakeAPIServer{resources: allResources, controllerCode: http.StatusForbidden},
+			want: []string{
+				"[FAIL] Shipwright Build controller ready: not allowed to get Deployment shipwright-build/shipwright-build-controller",
+			},
+		},
+		{
+			name:   "Tekton not installed",
+			server: fakeAPIServer{resources: allResources, controllerCode: http.StatusOK, readyReplicas: 1, withoutTekton: true},
+			want: []string{
+				"[FAIL] Tekton Pipelines installed: API group tekton.dev is not served",
+				"Fix: Install Tekton Pipelines",
+			},
+		},
+		{
+			name:   "missing permission",
+			server: fakeAPIServer{resources: allResources, controllerCode: http.StatusOK, readyReplicas: 1, deniedAccesses: []string{"create buildruns"}},
+			want: []string{
+				"[FAIL] Permissions in namespace 'team-a': missing create buildruns",
+				"Fix: Ask a cluster administrator to grant create buildruns in namespace 'team-a'",
+				"Result: 4 passed, 1 failed",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(&test.server)
+			t.Cleanup(server.Close)
+			clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := addTestCluster(t, &Cluster{Host: server.URL, Clientset: clientset})
+
+			result, _, err := CheckCluster(ctx, nil, models.CheckClusterParams{Namespace: "team-a"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			text := result.Content[0].(*mcp.TextContent).Text
+			for _, want := range test.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("output does not contain %q\ngot\n%s", want, text)
+				}
+			}
+		})
+	}
+}
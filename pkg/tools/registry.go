@@ -0,0 +1,134 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+type Definition struct {
+	Name        string
+	Description string
+	ReadOnly    bool
+	// permissions are the Kubernetes actions the tool performs in the
+	// namespace it works in, checked by check_permissions and check_cluster.
+	permissions []permission
+	register    func(server *mcp.Server, tool *mcp.Tool)
+}
+
+func define[In models.NamespacedParams](name, description string, readOnly bool, handler mcp.ToolHandlerFor[In, any], permissions ...permission) Definition {
+	return Definition{
+		Name:        name,
+		Description: description,
+		ReadOnly:    readOnly,
+		permissions: permissions,
+		register: func(server *mcp.Server, tool *mcp.Tool) {
+			handler := withNamespacePolicy(handler)
+			if !readOnly {
+				handler = withAudit(name, handler)
+			}
+			mcp.AddTool(server, tool, withTracing(name, withLogging(name, withMetrics(name, withClusterContext(withCacheStatus(handler))))))
+		},
+	}
+}
+
+// withClusterContext selects the cluster named by the context argument of
+// tools whose params embed models.ClusterParams before calling handler.
+func withClusterContext[In any](handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		if params, ok := any(args).(interface{ ClusterContext() string }); ok {
+			cluster, err := lookupCluster(params.ClusterContext())
+			if err != nil {
+				return &mcp.CallToolResult{
+					IsError: true,
+					Content: []mcp.Content{&mcp.TextContent{Text: err.Error()}},
+				}, nil, nil
+			}
+			ctx = withCluster(ctx, cluster)
+		}
+		return handler(ctx, req, args)
+	}
+}
+
+var Definitions = []Definition{
+	define("list_builds", "List Builds in a namespace with filtering options", true, ListBuilds, shipwright("list", "builds"), optional(listNamespaces)),
+	define("get_build", "Get a specific Build by name", true, GetBuild, shipwright("get", "builds")),
+	define("create_build", "Create a new Build resource", false, CreateBuild, shipwright("create", "builds")),
+	define("copy_build", "Copy a Build to a new name and/or namespace with optional overrides", false, CopyBuild, shipwright("get", "builds"), shipwright("create", "builds"), optional(core("get", "secrets", "")), optional(shipwright("get", "buildstrategies")), optional(shipwright("get", "clusterbuildstrategies"))),
+	define("diff_builds", "Show field-level differences between two Builds, or between a Build and a YAML manifest", true, DiffBuilds, shipwright("get", "builds")),
+	define("delete_build", "Delete a Build resource", false, DeleteBuild, shipwright("get", "builds"), shipwright("list", "buildruns"), shipwright("delete", "builds"), optional(shipwright("delete", "buildruns"))),
+	define("get_build_stats", "Get BuildRun history statistics (success rate, durations, failure reasons) for one or all Builds in a namespace", true, GetBuildStats, shipwright("list", "buildruns")),
+	define("get_build_history", "List the most recent BuildRuns of a Build and show when it started failing", true, GetBuildHistory, shipwright("list", "buildruns")),
+	define("list_buildruns", "List BuildRuns in a namespace with filtering, sorting and pagination options", true, ListBuildRuns, shipwright("list", "buildruns"), optional(listNamespaces)),
+	define("get_buildrun", "Get a specific BuildRun by name", true, GetBuildRun, shipwright("get", "buildruns")),
+	define("create_buildrun", "Create a new BuildRun resource (either from existing Build or inline)", false, CreateBuildRun, shipwright("create", "buildruns")),
+	define(LocalSourceTool, "Bundle a local directory into an OCI artifact, push it to a registry and create a BuildRun that builds from it", false, CreateBuildRunFromLocalSource, optional(shipwright("get", "builds")), shipwright("create", "buildruns")),
+	define("restart_buildrun", "Restart a BuildRun by creating a new one", false, RestartBuildRun, shipwright("get", "buildruns"), shipwright("create", "buildruns")),
+	define("delete_buildrun", "Delete a BuildRun resource", false, DeleteBuildRun, shipwright("get", "buildruns"), shipwright("delete", "buildruns")),
+	define("diagnose_buildrun", "Diagnose a failed BuildRun using its failure details, log tail, pod events and Build registration status", true, DiagnoseBuildRun, shipwright("get", "buildruns"), optional(shipwright("get", "builds")), optional(core("list", "pods", "")), optional(core("get", "pods", "")), optional(core("get", "pods", "log")), optional(core("list", "events", ""))),
+	define("list_buildstrategies", "List BuildStrategies in a namespace with filtering options", true, ListBuildStrategies, shipwright("list", "buildstrategies"), optional(listNamespaces)),
+	define("list_clusterbuildstrategies", "List ClusterBuildStrategies with filtering options", true, ListClusterBuildStrategies, shipwright("list", "clusterbuildstrategies")),
+	define("export_resource", "Export a Build, BuildRun, BuildStrategy or ClusterBuildStrategy as clean YAML for GitOps", true, ExportResource, optional(shipwright("get", "builds")), optional(shipwright("get", "buildruns")), optional(shipwright("get", "buildstrategies")), optional(shipwright("get", "clusterbuildstrategies"))),
+	define("list_clusters", "List the configured clusters (kubeconfig contexts) with their reachability and Shipwright version", true, ListClusters),
+	define("check_cluster", "Check that the cluster is ready for Shipwright Build: CRDs, controller, Tekton and the caller's permissions, with remediation hints", true, CheckCluster),
+	define("check_permissions", "Check which tools the caller can use in a namespace, using SelfSubjectAccessReviews for every permission the tools need", true, CheckPermissions),
+	define("set_default_namespace", "Set the namespace used by tools called without one for the rest of this MCP session on the selected cluster", true, SetDefaultNamespace),
+}
+
+func (d Definition) Register(server *mcp.Server) {
+	registeredTools = append(registeredTools, d)
+	d.register(server, &mcp.Tool{
+		Name:        d.Name,
+		Description: d.Description,
+		Annotations: &mcp.ToolAnnotations{ReadOnlyHint: d.ReadOnly},
+	})
+}
+
+// SelectTools returns the definitions to register. When enable is not empty,
+// only tools matching one of its patterns are kept, and tools matching a
+// pattern in disable are always left out. Patterns are tool names or globs
+// such as "create_*". A pattern that matches no tool is reported as an error
+// so that typos do not silently change the tool set.
+func SelectTools(readOnly bool, enable, disable []string) ([]Definition, error) {
+	for _, pattern := range append(append([]string{}, enable...), disable...) {
+		matched := false
+		for _, definition := range Definitions {
+			ok, err := path.Match(pattern, definition.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tool pattern '%s': %v", pattern, err)
+			}
+			matched = matched || ok
+		}
+		if !matched {
+			return nil, fmt.Errorf("tool pattern '%s' does not match any tool", pattern)
+		}
+	}
+
+	var selected []Definition
+	for _, definition := range Definitions {
+		if readOnly && !definition.ReadOnly {
+			continue
+		}
+		if len(enable) > 0 && !matchesAny(enable, definition.Name) {
+			continue
+		}
+		if matchesAny(disable, definition.Name) {
+			continue
+		}
+		selected = append(selected, definition)
+	}
+	return selected, nil
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
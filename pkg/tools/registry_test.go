@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSelectTools(t *testing.T) {
+	var all, readOnly []string
+	for _, definition := range Definitions {
+		all = append(all, definition.Name)
+		if definition.ReadOnly {
+			readOnly = append(readOnly, definition.Name)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		readOnly bool
+		enable   []string
+		disable  []string
+		want     []string
+	}{
+		{"all tools", false, nil, nil, all},
+		{"read-only", true, nil, nil, readOnly},
+		{"enable names", false, []string{"get_build", "list_builds"}, nil, []string{"list_builds", "get_build"}},
+		{"enable glob", false, []string{"delete_*"}, nil, []string{"delete_build", "delete_buildrun"}},
+		{"disable wins over enable", false, []string{"delete_*"}, []string{"delete_build"}, []string{"delete_buildrun"}},
+		{"read-only filters enabled tools", true, []string{"*_build"}, nil, []string{"get_build"}},
+		{"disable glob", false, nil, []string{"*"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			definitions, err := SelectTools(test.readOnly, test.enable, test.disable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, definition := range definitions {
+				got = append(got, definition.Name)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("SelectTools() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSelectToolsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		enable  []string
+		disable []string
+		problem string
+	}{
+		{"typo in enable", []string{"get_biuld"}, nil, "tool pattern 'get_biuld' does not match any tool"},
+		{"typo in disable", nil, []string{"delete_*s"}, "tool pattern 'delete_*s' does not match any tool"},
+		{"invalid glob", []string{"get_["}, nil, "invalid tool pattern 'get_['"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := SelectTools(false, test.enable, test.disable)
+			if err == nil || !strings.Contains(err.Error(), test.problem) {
+				t.Errorf("SelectTools() = %v, want an error containing %q", err, test.problem)
+			}
+		})
+	}
+}
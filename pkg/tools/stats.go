@@ -0,0 +1,193 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+const topFailureReasons = 3
+
+type buildStats struct {
+	statusCounts   map[string]int
+	durations      map[string][]time.Duration
+	failureReasons map[string]int
+	lastSuccess    *time.Time
+}
+
+func GetBuildStats(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildStatsParams) (*mcp.CallToolResult, any, error) {
+	namespace := resolveNamespace(ctx, req, args.Namespace)
+
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+	}
+	if args.BuildName != "" {
+		listOpts = append(listOpts, client.MatchingLabels{buildv1beta1.LabelBuild: args.BuildName})
+	}
+
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := kubeClient(ctx).List(ctx, buildRunList, listOpts...); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list buildruns: %v", err)}},
+		}, nil, nil
+	}
+
+	statsByBuild := map[string]*buildStats{}
+	for i := range buildRunList.Items {
+		buildRun := &buildRunList.Items[i]
+		buildName := buildRunBuildName(buildRun)
+		if buildName == "" {
+			continue
+		}
+
+		stats, ok := statsByBuild[buildName]
+		if !ok {
+			stats = &buildStats{
+				statusCounts:   map[string]int{},
+				durations:      map[string][]time.Duration{},
+				failureReasons: map[string]int{},
+			}
+			statsByBuild[buildName] = stats
+		}
+
+		status := buildRunStatus(buildRun)
+		stats.statusCounts[status]++
+		if status == "Failed" {
+			stats.failureReasons[buildRunFailureReason(buildRun)]++
+		}
+		if (status == "Succeeded" || status == "Failed") && buildRun.Status.StartTime != nil && buildRun.Status.CompletionTime != nil {
+			stats.durations[status] = append(stats.durations[status], buildRun.Status.CompletionTime.Sub(buildRun.Status.StartTime.Time))
+		}
+		if status == "Succeeded" && buildRun.Status.CompletionTime != nil {
+			if stats.lastSuccess == nil || buildRun.Status.CompletionTime.After(*stats.lastSuccess) {
+				completed := buildRun.Status.CompletionTime.Time
+				stats.lastSuccess = &completed
+			}
+		}
+	}
+
+	if len(statsByBuild) == 0 {
+		if args.BuildName != "" {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No buildruns found for Build '%s' in namespace '%s'", args.BuildName, namespace)}},
+			}, nil, nil
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("No buildruns found in namespace '%s'", namespace)}},
+		}, nil, nil
+	}
+
+	buildNames := make([]string, 0, len(statsByBuild))
+	for buildName := range statsByBuild {
+		buildNames = append(buildNames, buildName)
+	}
+	sort.Strings(buildNames)
+
+	now := time.Now()
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("BuildRun statistics for %d build(s) in namespace '%s':\n\n", len(buildNames), namespace))
+
+	for _, buildName := range buildNames {
+		stats := statsByBuild[buildName]
+
+		total := 0
+		for _, count := range stats.statusCounts {
+			total += count
+		}
+
+		result.WriteString(fmt.Sprintf("Build: %s\n", buildName))
+		result.WriteString(fmt.Sprintf("Total Runs: %d (", total))
+		for i, status := range buildRunStatuses {
+			if i > 0 {
+				result.WriteString(", ")
+			}
+			result.WriteString(fmt.Sprintf("%s: %d", status, stats.statusCounts[status]))
+		}
+		result.WriteString(")\n")
+
+		finished := stats.statusCounts["Succeeded"] + stats.statusCounts["Failed"]
+		if finished > 0 {
+			result.WriteString(fmt.Sprintf("Success Rate: %.1f%%\n", float64(stats.statusCounts["Succeeded"])*100/float64(finished)))
+		} else {
+			result.WriteString("Success Rate: n/a\n")
+		}
+
+		writeDurations(&result, "Succeeded", stats.durations["Succeeded"])
+		writeDurations(&result, "Failed", stats.durations["Failed"])
+
+		if len(stats.failureReasons) > 0 {
+			reasons := make([]string, 0, len(stats.failureReasons))
+			for reason := range stats.failureReasons {
+				reasons = append(reasons, reason)
+			}
+			sort.Slice(reasons, func(i, j int) bool {
+				if stats.failureReasons[reasons[i]] != stats.failureReasons[reasons[j]] {
+					return stats.failureReasons[reasons[i]] > stats.failureReasons[reasons[j]]
+				}
+				return reasons[i] < reasons[j]
+			})
+			if len(reasons) > topFailureReasons {
+				reasons = reasons[:topFailureReasons]
+			}
+			result.WriteString("Top Failure Reasons:\n")
+			for _, reason := range reasons {
+				result.WriteString(fmt.Sprintf("  %s: %d\n", reason, stats.failureReasons[reason]))
+			}
+		}
+
+		if stats.lastSuccess != nil {
+			result.WriteString(fmt.Sprintf("Last Success: %s ago (%s)\n", now.Sub(*stats.lastSuccess).Round(time.Second), stats.lastSuccess.Format("2006-01-02 15:04:05")))
+		} else {
+			result.WriteString("Last Success: never\n")
+		}
+		result.WriteString("---\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+	}, nil, nil
+}
+
+func buildRunBuildName(buildRun *buildv1beta1.BuildRun) string {
+	if name := buildRun.Spec.BuildName(); name != "" {
+		return name
+	}
+	return buildRun.Labels[buildv1beta1.LabelBuild]
+}
+
+// writeDurations reports the durations of the runs that ended with status
+// separately, since failed runs often stop early and would skew the
+// durations of successful ones.
+func writeDurations(result *strings.Builder, status string, durations []time.Duration) {
+	if len(durations) == 0 {
+		return
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	mean := sum / time.Duration(len(durations))
+	result.WriteString(fmt.Sprintf("Duration (%s): mean %s, p50 %s, p95 %s\n",
+		status,
+		mean.Round(time.Second),
+		percentile(durations, 50).Round(time.Second),
+		percentile(durations, 95).Round(time.Second)))
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
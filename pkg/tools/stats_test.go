@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	durations := func(seconds ...int) []time.Duration {
+		var result []time.Duration
+		for _, s := range seconds {
+			result = append(result, time.Duration(s)*time.Second)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		p      int
+		want   time.Duration
+	}{
+		{"single run", durations(42), 50, 42 * time.Second},
+		{"single run p95", durations(42), 95, 42 * time.Second},
+		{"median of odd count", durations(1, 2, 3), 50, 2 * time.Second},
+		{"median of even count", durations(1, 2, 3, 4), 50, 2 * time.Second},
+		{"p95 of two", durations(10, 20), 95, 20 * time.Second},
+		{"p95 of twenty", durations(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20), 95, 19 * time.Second},
+		{"p0", durations(5, 6, 7), 0, 5 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := percentile(test.sorted, test.p); got != test.want {
+				t.Errorf("percentile(%v, %d) = %s, want %s", test.sorted, test.p, got, test.want)
+			}
+		})
+	}
+}
@@ -13,55 +13,79 @@ import (
 	"github.com/shipwright-io/build/server/pkg/models"
 )
 
-func ListBuildStrategies(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.ListBuildStrategiesParams]) (*mcp.CallToolResultFor[any], error) {
-	buildStrategyList := &buildv1beta1.BuildStrategyList{}
-
-	listOpts := []client.ListOption{
-		client.InNamespace(params.Arguments.Namespace),
+func ListBuildStrategies(ctx context.Context, req *mcp.CallToolRequest, args models.ListBuildStrategiesParams) (*mcp.CallToolResult, any, error) {
+	scope := namespaceScope{
+		namespace:     args.Namespace,
+		namespaces:    args.Namespaces,
+		allNamespaces: args.AllNamespaces,
+	}
+	if err := scope.validate(); err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid namespace scope: %v", err)}},
+		}, nil, nil
+	}
+	if !scope.multiple() {
+		scope.namespace = resolveNamespace(ctx, req, scope.namespace)
 	}
 
-	if params.Arguments.LabelSelector != "" {
-		selector, err := metav1.ParseToLabelSelector(params.Arguments.LabelSelector)
+	var listOpts []client.ListOption
+
+	if args.LabelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(args.LabelSelector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		selectorObj, err := metav1.LabelSelectorAsSelector(selector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selectorObj})
 	}
 
-	if err := k8sClient.List(ctx, buildStrategyList, listOpts...); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	buildStrategyLists, skipped, err := listInScope(ctx, scope, func() *buildv1beta1.BuildStrategyList { return &buildv1beta1.BuildStrategyList{} }, listOpts)
+	if err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list buildstrategies: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	var strategies []buildv1beta1.BuildStrategy
-	for _, strategy := range buildStrategyList.Items {
-		if params.Arguments.Prefix == "" || strings.HasPrefix(strategy.Name, params.Arguments.Prefix) {
-			strategies = append(strategies, strategy)
+	for _, buildStrategyList := range buildStrategyLists {
+		for _, strategy := range buildStrategyList.Items {
+			if args.Prefix == "" || strings.HasPrefix(strategy.Name, args.Prefix) {
+				strategies = append(strategies, strategy)
+			}
 		}
 	}
 
 	if len(strategies) == 0 {
-		return &mcp.CallToolResultFor[any]{
-			Content: []mcp.Content{&mcp.TextContent{Text: "No buildstrategies found"}},
-		}, nil
+		var result strings.Builder
+		result.WriteString("No buildstrategies found")
+		writeSkippedNamespaces(&result, skipped)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
+		}, nil, nil
 	}
 
 	var result strings.Builder
 	result.WriteString(fmt.Sprintf("Found %d buildstrategy(ies):\n\n", len(strategies)))
 
+	var currentNamespace string
+	if scope.multiple() {
+		sortByNamespace(strategies, func(s *buildv1beta1.BuildStrategy) string { return s.Namespace })
+	}
 	for _, strategy := range strategies {
+		if scope.multiple() {
+			writeNamespaceHeader(&result, strategy.Namespace, &currentNamespace)
+		}
 		result.WriteString(fmt.Sprintf("Name: %s\n", strategy.Name))
 		result.WriteString(fmt.Sprintf("Namespace: %s\n", strategy.Namespace))
 		result.WriteString(fmt.Sprintf("Steps: %d\n", len(strategy.Spec.Steps)))
@@ -71,53 +95,54 @@ func ListBuildStrategies(ctx context.Context, cc *mcp.ServerSession, params *mcp
 		result.WriteString(fmt.Sprintf("Created: %s\n", strategy.CreationTimestamp.Format("2006-01-02 15:04:05")))
 		result.WriteString("---\n")
 	}
+	writeSkippedNamespaces(&result, skipped)
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
 
-func ListClusterBuildStrategies(ctx context.Context, cc *mcp.ServerSession, params *mcp.CallToolParamsFor[models.ListClusterBuildStrategiesParams]) (*mcp.CallToolResultFor[any], error) {
+func ListClusterBuildStrategies(ctx context.Context, req *mcp.CallToolRequest, args models.ListClusterBuildStrategiesParams) (*mcp.CallToolResult, any, error) {
 	clusterBuildStrategyList := &buildv1beta1.ClusterBuildStrategyList{}
 
 	var listOpts []client.ListOption
 
-	if params.Arguments.LabelSelector != "" {
-		selector, err := metav1.ParseToLabelSelector(params.Arguments.LabelSelector)
+	if args.LabelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(args.LabelSelector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		selectorObj, err := metav1.LabelSelectorAsSelector(selector)
 		if err != nil {
-			return &mcp.CallToolResultFor[any]{
+			return &mcp.CallToolResult{
 				IsError: true,
 				Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Invalid label selector: %v", err)}},
-			}, nil
+			}, nil, nil
 		}
 		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selectorObj})
 	}
 
-	if err := k8sClient.List(ctx, clusterBuildStrategyList, listOpts...); err != nil {
-		return &mcp.CallToolResultFor[any]{
+	if err := kubeClient(ctx).List(ctx, clusterBuildStrategyList, listOpts...); err != nil {
+		return &mcp.CallToolResult{
 			IsError: true,
 			Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Failed to list clusterbuildstrategies: %v", err)}},
-		}, nil
+		}, nil, nil
 	}
 
 	var strategies []buildv1beta1.ClusterBuildStrategy
 	for _, strategy := range clusterBuildStrategyList.Items {
-		if params.Arguments.Prefix == "" || strings.HasPrefix(strategy.Name, params.Arguments.Prefix) {
+		if args.Prefix == "" || strings.HasPrefix(strategy.Name, args.Prefix) {
 			strategies = append(strategies, strategy)
 		}
 	}
 
 	if len(strategies) == 0 {
-		return &mcp.CallToolResultFor[any]{
+		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: "No clusterbuildstrategies found"}},
-		}, nil
+		}, nil, nil
 	}
 
 	var result strings.Builder
@@ -133,7 +158,7 @@ func ListClusterBuildStrategies(ctx context.Context, cc *mcp.ServerSession, para
 		result.WriteString("---\n")
 	}
 
-	return &mcp.CallToolResultFor[any]{
+	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: result.String()}},
-	}, nil
+	}, nil, nil
 }
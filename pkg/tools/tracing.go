@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/tracing"
+)
+
+// withTracing runs every call of the tool in a span, continuing the trace the
+// client passed in the request's _meta field.
+func withTracing[In any](name string, handler mcp.ToolHandlerFor[In, any]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, any, error) {
+		attributes := []attribute.KeyValue{
+			attribute.String("mcp.method.name", "tools/call"),
+			attribute.String("gen_ai.tool.name", name),
+		}
+		if req != nil && req.Params != nil && req.Params.Meta != nil {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, tracing.MetaCarrier(req.Params.Meta))
+		}
+		if req != nil && req.Session != nil && req.Session.ID() != "" {
+			attributes = append(attributes, attribute.String("mcp.session.id", req.Session.ID()))
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, "tools/call "+name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+		defer span.End()
+
+		result, out, err := handler(ctx, req, args)
+		switch {
+		case err != nil:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case result != nil && result.IsError:
+			message := "tool call failed"
+			if len(result.Content) > 0 {
+				if text, ok := result.Content[0].(*mcp.TextContent); ok {
+					message = text.Text
+				}
+			}
+			span.SetStatus(codes.Error, message)
+		}
+		return result, out, err
+	}
+}
+
+// tracingClient runs every call of the wrapped client in a child span of the
+// tool call.
+type tracingClient struct {
+	client.Client
+	cluster string
+}
+
+func (c tracingClient) start(ctx context.Context, operation string, obj runtime.Object, namespace, name string) (context.Context, trace.Span) {
+	kind := "unknown"
+	if gvk, err := c.Client.GroupVersionKindFor(obj); err == nil {
+		kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	attributes := []attribute.KeyValue{
+		attribute.String("k8s.cluster.name", c.cluster),
+		attribute.String("k8s.operation", operation),
+		attribute.String("k8s.resource.kind", kind),
+	}
+	if namespace != "" {
+		attributes = append(attributes, attribute.String("k8s.namespace.name", namespace))
+	}
+	if name != "" {
+		attributes = append(attributes, attribute.String("k8s.object.name", name))
+	}
+	return tracing.Tracer().Start(ctx, operation+" "+kind, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (c tracingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := c.start(ctx, "Get", obj, key.Namespace, key.Name)
+	err := c.Client.Get(ctx, key, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	ctx, span := c.start(ctx, "List", list, listOptions.Namespace, "")
+	err := c.Client.List(ctx, list, opts...)
+	if err == nil {
+		if items, extractErr := meta.ExtractList(list); extractErr == nil {
+			span.SetAttributes(attribute.Int("k8s.list.items", len(items)))
+		}
+	}
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.start(ctx, "Create", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Create(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.start(ctx, "Update", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Update(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.start(ctx, "Patch", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.start(ctx, "Delete", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Delete(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
+
+func (c tracingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	deleteAllOfOptions := &client.DeleteAllOfOptions{}
+	deleteAllOfOptions.ApplyOptions(opts)
+	ctx, span := c.start(ctx, "DeleteAllOf", obj, deleteAllOfOptions.Namespace, "")
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	endSpan(span, err)
+	return err
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/shipwright-io/build/server/pkg/models"
+)
+
+// recordSpans installs a tracer provider that records every ended span for
+// the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestWithTracing(t *testing.T) {
+	const (
+		traceID  = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID = "00f067aa0ba902b7"
+	)
+	succeed := func(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildParams) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Build: app"}}}, nil, nil
+	}
+
+	tests := []struct {
+		name        string
+		meta        mcp.Meta
+		handler     mcp.ToolHandlerFor[models.GetBuildParams, any]
+		remote      bool
+		status      codes.Code
+		description string
+	}{
+		{name: "continues the client's trace", meta: mcp.Meta{"traceparent": "00-" + traceID + "-" + parentID + "-01"}, handler: succeed, remote: true},
+		{name: "starts a trace without _meta", handler: succeed},
+		{name: "ignores an invalid traceparent", meta: mcp.Meta{"traceparent": "00-invalid-01"}, handler: succeed},
+		{
+			name: "tool error",
+			handler: func(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildParams) (*mcp.CallToolResult, any, error) {
+				return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "Build 'app' not found"}}}, nil, nil
+			},
+			status:      codes.Error,
+			description: "Build 'app' not found",
+		},
+		{
+			name: "handler error",
+			handler: func(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildParams) (*mcp.CallToolResult, any, error) {
+				return nil, nil, errors.New("connection refused")
+			},
+			status:      codes.Error,
+			description: "connection refused",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := recordSpans(t)
+			req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_build", Meta: test.meta}}
+
+			_, _, _ = withTracing("get_build", test.handler)(context.Background(), req, models.GetBuildParams{})
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != "tools/call get_build" {
+				t.Errorf("span name = %s", span.Name())
+			}
+			if span.Parent().IsRemote() != test.remote {
+				t.Errorf("remote parent = %v, want %v", span.Parent().IsRemote(), test.remote)
+			}
+			if test.remote && (span.SpanContext().TraceID().String() != traceID || span.Parent().SpanID().String() != parentID) {
+				t.Errorf("span %s/%s does not continue trace %s/%s", span.SpanContext().TraceID(), span.Parent().SpanID(), traceID, parentID)
+			}
+			if span.Status().Code != test.status || span.Status().Description != test.description {
+				t.Errorf("status = %v %q, want %v %q", span.Status().Code, span.Status().Description, test.status, test.description)
+			}
+		})
+	}
+}
+
+func TestTracingClientSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx := addTestCluster(t, &Cluster{}, testBuild("buildah"))
+
+	handler := withTracing("get_build", func(ctx context.Context, req *mcp.CallToolRequest, args models.GetBuildParams) (*mcp.CallToolResult, any, error) {
+		build := &buildv1beta1.Build{}
+		return &mcp.CallToolResult{}, nil, kubeClient(ctx).Get(ctx, client.ObjectKey{Name: "app", Namespace: "team-a"}, build)
+	})
+	if _, _, err := handler(ctx, nil, models.GetBuildParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want the Kubernetes call and the tool call", len(spans))
+	}
+	call, tool := spans[0], spans[1]
+	if call.Name() != "Get Build" || call.Parent().SpanID() != tool.SpanContext().SpanID() {
+		t.Errorf("span %s with parent %s, want Get Build as a child of %s", call.Name(), call.Parent().SpanID(), tool.SpanContext().SpanID())
+	}
+	want := map[attribute.Key]string{
+		"k8s.cluster.name":   "test",
+		"k8s.namespace.name": "team-a",
+		"k8s.object.name":    "app",
+		"k8s.resource.kind":  "Build",
+	}
+	for _, attr := range call.Attributes() {
+		if value, ok := want[attr.Key]; ok {
+			if attr.Value.AsString() != value {
+				t.Errorf("%s = %s, want %s", attr.Key, attr.Value.AsString(), value)
+			}
+			delete(want, attr.Key)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("missing attributes %v", want)
+	}
+}